@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// startAdminSpan starts a client span for a Kafka operation which isn't
+// producing or consuming messages.
+func startAdminSpan(cfg config, name string, attrs ...kv.KeyValue) trace.Span {
+	attrs = append([]kv.KeyValue{
+		standard.ServiceNameKey.String(cfg.ServiceName),
+		standard.MessagingSystemKey.String("kafka"),
+	}, attrs...)
+	_, span := cfg.Tracer.Start(context.Background(), name,
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	return span
+}
+
+func finishAdminSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Internal, err.Error())
+	}
+	span.End()
+}
+
+// topicAttributes returns the destination attributes of an operation on
+// topic.
+func topicAttributes(topic string) []kv.KeyValue {
+	return []kv.KeyValue{
+		standard.MessagingDestinationKindKeyTopic,
+		standard.MessagingDestinationKey.String(topic),
+	}
+}
+
+type clusterAdmin struct {
+	sarama.ClusterAdmin
+	cfg config
+}
+
+// WrapClusterAdmin wraps a sarama.ClusterAdmin so that all admin operations
+// are traced.
+func WrapClusterAdmin(serviceName string, admin sarama.ClusterAdmin, opts ...Option) sarama.ClusterAdmin {
+	return &clusterAdmin{
+		ClusterAdmin: admin,
+		cfg:          newConfig(serviceName, opts...),
+	}
+}
+
+// CreateTopic calls sarama.ClusterAdmin.CreateTopic and traces the request.
+func (a *clusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+	attrs := topicAttributes(topic)
+	if detail != nil {
+		attrs = append(attrs, kafkaPartitionCountKey.Int32(detail.NumPartitions))
+	}
+	span := startAdminSpan(a.cfg, "kafka.admin.create_topic", attrs...)
+	err := a.ClusterAdmin.CreateTopic(topic, detail, validateOnly)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// ListTopics calls sarama.ClusterAdmin.ListTopics and traces the request.
+func (a *clusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.list_topics")
+	topics, err := a.ClusterAdmin.ListTopics()
+	finishAdminSpan(span, err)
+	return topics, err
+}
+
+// DescribeTopics calls sarama.ClusterAdmin.DescribeTopics and traces the
+// request.
+func (a *clusterAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.describe_topics")
+	metadata, err := a.ClusterAdmin.DescribeTopics(topics)
+	finishAdminSpan(span, err)
+	return metadata, err
+}
+
+// DeleteTopic calls sarama.ClusterAdmin.DeleteTopic and traces the request.
+func (a *clusterAdmin) DeleteTopic(topic string) error {
+	span := startAdminSpan(a.cfg, "kafka.admin.delete_topic", topicAttributes(topic)...)
+	err := a.ClusterAdmin.DeleteTopic(topic)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// CreatePartitions calls sarama.ClusterAdmin.CreatePartitions and traces the
+// request.
+func (a *clusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	attrs := append(topicAttributes(topic), kafkaPartitionCountKey.Int32(count))
+	span := startAdminSpan(a.cfg, "kafka.admin.create_partitions", attrs...)
+	err := a.ClusterAdmin.CreatePartitions(topic, count, assignment, validateOnly)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// AlterPartitionReassignments calls
+// sarama.ClusterAdmin.AlterPartitionReassignments and traces the request.
+func (a *clusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
+	span := startAdminSpan(a.cfg, "kafka.admin.alter_partition_reassignments", topicAttributes(topic)...)
+	err := a.ClusterAdmin.AlterPartitionReassignments(topic, assignment)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// ListPartitionReassignments calls
+// sarama.ClusterAdmin.ListPartitionReassignments and traces the request.
+func (a *clusterAdmin) ListPartitionReassignments(topic string, partitions []int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.list_partition_reassignments", topicAttributes(topic)...)
+	status, err := a.ClusterAdmin.ListPartitionReassignments(topic, partitions)
+	finishAdminSpan(span, err)
+	return status, err
+}
+
+// DeleteRecords calls sarama.ClusterAdmin.DeleteRecords and traces the
+// request, with the number of partitions whose records are deleted.
+func (a *clusterAdmin) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	attrs := append(topicAttributes(topic), kafkaPartitionsTrimmedKey.Int(len(partitionOffsets)))
+	span := startAdminSpan(a.cfg, "kafka.admin.delete_records", attrs...)
+	err := a.ClusterAdmin.DeleteRecords(topic, partitionOffsets)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// DescribeConfig calls sarama.ClusterAdmin.DescribeConfig and traces the
+// request.
+func (a *clusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.describe_config", kafkaResourceKey.String(resource.Name))
+	entries, err := a.ClusterAdmin.DescribeConfig(resource)
+	finishAdminSpan(span, err)
+	return entries, err
+}
+
+// AlterConfig calls sarama.ClusterAdmin.AlterConfig and traces the request.
+func (a *clusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
+	span := startAdminSpan(a.cfg, "kafka.admin.alter_config", kafkaResourceKey.String(name))
+	err := a.ClusterAdmin.AlterConfig(resourceType, name, entries, validateOnly)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// CreateACL calls sarama.ClusterAdmin.CreateACL and traces the request.
+func (a *clusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
+	span := startAdminSpan(a.cfg, "kafka.admin.create_acl", kafkaResourceKey.String(resource.ResourceName))
+	err := a.ClusterAdmin.CreateACL(resource, acl)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// ListAcls calls sarama.ClusterAdmin.ListAcls and traces the request.
+func (a *clusterAdmin) ListAcls(filter sarama.AclFilter) ([]sarama.ResourceAcls, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.list_acls")
+	acls, err := a.ClusterAdmin.ListAcls(filter)
+	finishAdminSpan(span, err)
+	return acls, err
+}
+
+// DeleteACL calls sarama.ClusterAdmin.DeleteACL and traces the request.
+func (a *clusterAdmin) DeleteACL(filter sarama.AclFilter, validateOnly bool) ([]sarama.MatchingAcl, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.delete_acl")
+	acls, err := a.ClusterAdmin.DeleteACL(filter, validateOnly)
+	finishAdminSpan(span, err)
+	return acls, err
+}
+
+// ListConsumerGroups calls sarama.ClusterAdmin.ListConsumerGroups and traces
+// the request.
+func (a *clusterAdmin) ListConsumerGroups() (map[string]string, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.list_consumer_groups")
+	groups, err := a.ClusterAdmin.ListConsumerGroups()
+	finishAdminSpan(span, err)
+	return groups, err
+}
+
+// DescribeConsumerGroups calls sarama.ClusterAdmin.DescribeConsumerGroups and
+// traces the request.
+func (a *clusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	var attrs []kv.KeyValue
+	if len(groups) == 1 {
+		attrs = append(attrs, kafkaConsumerGroupKey.String(groups[0]))
+	}
+	span := startAdminSpan(a.cfg, "kafka.admin.describe_consumer_groups", attrs...)
+	descriptions, err := a.ClusterAdmin.DescribeConsumerGroups(groups)
+	finishAdminSpan(span, err)
+	return descriptions, err
+}
+
+// ListConsumerGroupOffsets calls sarama.ClusterAdmin.ListConsumerGroupOffsets
+// and traces the request.
+func (a *clusterAdmin) ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.list_consumer_group_offsets", kafkaConsumerGroupKey.String(group))
+	res, err := a.ClusterAdmin.ListConsumerGroupOffsets(group, topicPartitions)
+	if err == nil && res != nil && res.Err != sarama.ErrNoError {
+		span.SetStatus(codes.Internal, res.Err.Error())
+	}
+	finishAdminSpan(span, err)
+	return res, err
+}
+
+// DeleteConsumerGroup calls sarama.ClusterAdmin.DeleteConsumerGroup and
+// traces the request.
+func (a *clusterAdmin) DeleteConsumerGroup(group string) error {
+	span := startAdminSpan(a.cfg, "kafka.admin.delete_consumer_group", kafkaConsumerGroupKey.String(group))
+	err := a.ClusterAdmin.DeleteConsumerGroup(group)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// DescribeCluster calls sarama.ClusterAdmin.DescribeCluster and traces the
+// request.
+func (a *clusterAdmin) DescribeCluster() ([]*sarama.Broker, int32, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.describe_cluster")
+	brokers, controllerID, err := a.ClusterAdmin.DescribeCluster()
+	finishAdminSpan(span, err)
+	return brokers, controllerID, err
+}
+
+// DescribeLogDirs calls sarama.ClusterAdmin.DescribeLogDirs and traces the
+// request.
+func (a *clusterAdmin) DescribeLogDirs(brokers []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	span := startAdminSpan(a.cfg, "kafka.admin.describe_log_dirs")
+	dirs, err := a.ClusterAdmin.DescribeLogDirs(brokers)
+	finishAdminSpan(span, err)
+	return dirs, err
+}
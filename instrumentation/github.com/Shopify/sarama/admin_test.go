@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+
+	mocktracer "go.opentelemetry.io/contrib/internal/trace"
+)
+
+type stubClusterAdmin struct {
+	sarama.ClusterAdmin
+	err error
+}
+
+func (a *stubClusterAdmin) CreateTopic(string, *sarama.TopicDetail, bool) error {
+	return a.err
+}
+
+func (a *stubClusterAdmin) DeleteRecords(string, map[int32]int64) error {
+	return a.err
+}
+
+func (a *stubClusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	return nil, a.err
+}
+
+func TestWrapClusterAdmin(t *testing.T) {
+	mt := mocktracer.NewTracer("kafka")
+	stub := &stubClusterAdmin{}
+	admin := WrapClusterAdmin(serviceName, stub, WithTracer(mt))
+
+	require.NoError(t, admin.CreateTopic(topic, &sarama.TopicDetail{NumPartitions: 3}, false))
+	require.NoError(t, admin.DeleteRecords(topic, map[int32]int64{0: 10, 1: 20}))
+	stub.err = errors.New("test")
+	_, err := admin.DescribeConsumerGroups([]string{"group"})
+	require.Error(t, err)
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 3)
+
+	expectedList := []struct {
+		name   string
+		kvList []kv.KeyValue
+		status codes.Code
+	}{
+		{
+			name: "kafka.admin.create_topic",
+			kvList: []kv.KeyValue{
+				standard.ServiceNameKey.String(serviceName),
+				standard.MessagingSystemKey.String("kafka"),
+				standard.MessagingDestinationKindKeyTopic,
+				standard.MessagingDestinationKey.String(topic),
+				kafkaPartitionCountKey.Int32(3),
+			},
+		},
+		{
+			name: "kafka.admin.delete_records",
+			kvList: []kv.KeyValue{
+				standard.MessagingDestinationKey.String(topic),
+				kafkaPartitionsTrimmedKey.Int(2),
+			},
+		},
+		{
+			name: "kafka.admin.describe_consumer_groups",
+			kvList: []kv.KeyValue{
+				kafkaConsumerGroupKey.String("group"),
+			},
+			status: codes.Internal,
+		},
+	}
+	for i, expected := range expectedList {
+		span := spans[i]
+		assert.Equal(t, expected.name, span.Name)
+		assert.Equal(t, trace.SpanKindClient, span.Kind)
+		assert.Equal(t, expected.status, span.Status)
+		for _, k := range expected.kvList {
+			assert.Equal(t, k.Value, span.Attributes[k.Key], k.Key)
+		}
+	}
+	assert.Equal(t, "test", spans[2].StatusMessage)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"github.com/Shopify/sarama"
+
+	"go.opentelemetry.io/otel/api/kv"
+)
+
+type client struct {
+	sarama.Client
+	cfg config
+}
+
+// WrapClient wraps a sarama.Client so that the requests it makes to the
+// brokers to refresh its metadata or fetch offsets are traced. Calls served
+// from the cached metadata are not traced, and neither are the offset
+// commits of the offset managers created from the client, which sarama
+// sends from a background loop.
+func WrapClient(serviceName string, c sarama.Client, opts ...Option) sarama.Client {
+	return &client{
+		Client: c,
+		cfg:    newConfig(serviceName, opts...),
+	}
+}
+
+// RefreshMetadata calls sarama.Client.RefreshMetadata and traces the request.
+func (c *client) RefreshMetadata(topics ...string) error {
+	var attrs []kv.KeyValue
+	if len(topics) == 1 {
+		attrs = topicAttributes(topics[0])
+	}
+	span := startAdminSpan(c.cfg, "kafka.client.refresh_metadata", attrs...)
+	err := c.Client.RefreshMetadata(topics...)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// RefreshController calls sarama.Client.RefreshController and traces the
+// request.
+func (c *client) RefreshController() (*sarama.Broker, error) {
+	span := startAdminSpan(c.cfg, "kafka.client.refresh_controller")
+	broker, err := c.Client.RefreshController()
+	finishAdminSpan(span, err)
+	return broker, err
+}
+
+// RefreshCoordinator calls sarama.Client.RefreshCoordinator and traces the
+// request.
+func (c *client) RefreshCoordinator(consumerGroup string) error {
+	span := startAdminSpan(c.cfg, "kafka.client.refresh_coordinator", kafkaConsumerGroupKey.String(consumerGroup))
+	err := c.Client.RefreshCoordinator(consumerGroup)
+	finishAdminSpan(span, err)
+	return err
+}
+
+// GetOffset calls sarama.Client.GetOffset and traces the request.
+func (c *client) GetOffset(topic string, partitionID int32, time int64) (int64, error) {
+	attrs := append(topicAttributes(topic), kafkaPartitionKey.Int32(partitionID))
+	span := startAdminSpan(c.cfg, "kafka.client.get_offset", attrs...)
+	offset, err := c.Client.GetOffset(topic, partitionID, time)
+	finishAdminSpan(span, err)
+	return offset, err
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+
+	mocktracer "go.opentelemetry.io/contrib/internal/trace"
+)
+
+type stubClient struct {
+	sarama.Client
+}
+
+func (c *stubClient) Topics() ([]string, error) {
+	return []string{topic}, nil
+}
+
+func (c *stubClient) RefreshMetadata(...string) error {
+	return errors.New("test")
+}
+
+func (c *stubClient) GetOffset(string, int32, int64) (int64, error) {
+	return 42, nil
+}
+
+func TestWrapClient(t *testing.T) {
+	mt := mocktracer.NewTracer("kafka")
+	c := WrapClient(serviceName, &stubClient{}, WithTracer(mt))
+
+	_, err := c.Topics()
+	require.NoError(t, err)
+	require.Error(t, c.RefreshMetadata(topic))
+	offset, err := c.GetOffset(topic, 1, sarama.OffsetNewest)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), offset)
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 2, "cached metadata calls must not be traced")
+
+	assert.Equal(t, "kafka.client.refresh_metadata", spans[0].Name)
+	assert.Equal(t, kv.StringValue(topic), spans[0].Attributes[standard.MessagingDestinationKey])
+	assert.Equal(t, codes.Internal, spans[0].Status)
+
+	assert.Equal(t, "kafka.client.get_offset", spans[1].Name)
+	assert.Equal(t, kv.Int32Value(1), spans[1].Attributes[kafkaPartitionKey])
+	assert.Equal(t, codes.OK, spans[1].Status)
+}
@@ -18,3 +18,64 @@ package sarama
 // Currently, sarama does not have a mock consumer group, so it's hard to
 // write a unit test.
 // Related PR: https://github.com/Shopify/sarama/pull/1750
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
+	mocktracer "go.opentelemetry.io/contrib/internal/trace"
+)
+
+type testConsumerGroupClaim struct {
+	messages chan *sarama.ConsumerMessage
+	hwm      int64
+}
+
+func (c *testConsumerGroupClaim) Topic() string                            { return topic }
+func (c *testConsumerGroupClaim) Partition() int32                         { return 0 }
+func (c *testConsumerGroupClaim) InitialOffset() int64                     { return 0 }
+func (c *testConsumerGroupClaim) HighWaterMarkOffset() int64               { return c.hwm }
+func (c *testConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+type testConsumerGroupHandler struct {
+	received []*sarama.ConsumerMessage
+}
+
+func (h *testConsumerGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *testConsumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+func (h *testConsumerGroupHandler) ConsumeClaim(_ sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		h.received = append(h.received, msg)
+	}
+	return nil
+}
+
+func TestWrapConsumerGroupHandlerClaimLag(t *testing.T) {
+	meterimpl, meter := mockmeter.NewMeter()
+	mt := mocktracer.NewTracer("kafka")
+
+	claim := &testConsumerGroupClaim{messages: make(chan *sarama.ConsumerMessage, 2), hwm: 10}
+	claim.messages <- &sarama.ConsumerMessage{Topic: topic, Offset: 4}
+	claim.messages <- &sarama.ConsumerMessage{Topic: topic, Offset: 5}
+	close(claim.messages)
+
+	handler := &testConsumerGroupHandler{}
+	wrapped := WrapConsumerGroupHandler(serviceName, handler, WithTracer(mt), WithMeter(meter))
+	require.NoError(t, wrapped.ConsumeClaim(nil, claim))
+	require.Len(t, handler.received, 2)
+	assert.Len(t, mt.EndedSpans(), 2)
+
+	var lags []int64
+	for _, batch := range meterimpl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			if m.Instrument.Descriptor().Name() == ConsumerLag {
+				lags = append(lags, m.Number.AsInt64())
+			}
+		}
+	}
+	assert.Equal(t, []int64{5, 4}, lags)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/Shopify/sarama/mocks"
@@ -30,6 +31,7 @@ import (
 	"go.opentelemetry.io/otel/api/standard"
 	"go.opentelemetry.io/otel/api/trace"
 
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
 	mocktracer "go.opentelemetry.io/contrib/internal/trace"
 )
 
@@ -209,3 +211,44 @@ func createMockPartitionConsumer(b *testing.B) (*mocks.PartitionConsumer, sarama
 	require.NoError(b, err)
 	return mockPartitionConsumer, partitionConsumer
 }
+
+func TestWrapPartitionConsumerMetrics(t *testing.T) {
+	meterimpl, meter := mockmeter.NewMeter()
+
+	consumer := mocks.NewConsumer(t, sarama.NewConfig())
+	mockPartitionConsumer := consumer.ExpectConsumePartition(topic, 0, 0)
+	partitionConsumer, err := consumer.ConsumePartition(topic, 0, 0)
+	require.NoError(t, err)
+	partitionConsumer = WrapPartitionConsumer(serviceName, partitionConsumer,
+		WithTracer(mocktracer.NewTracer("kafka")), WithMeter(meter))
+
+	mockPartitionConsumer.YieldMessage(&sarama.ConsumerMessage{Key: []byte("foo"), Timestamp: time.Now().Add(-time.Second)})
+	mockPartitionConsumer.YieldMessage(&sarama.ConsumerMessage{Key: []byte("foo2")})
+	<-partitionConsumer.Messages()
+	<-partitionConsumer.Messages()
+	require.NoError(t, partitionConsumer.Close())
+	// Wait for the channel to be closed
+	<-partitionConsumer.Messages()
+
+	got := map[string][]int64{}
+	for _, batch := range meterimpl.MeasurementBatches {
+		assert.Equal(t, []kv.KeyValue{
+			standard.MessagingDestinationKey.String(topic),
+			kafkaPartitionKey.Int32(0),
+		}, batch.Labels)
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			got[name] = append(got[name], m.Number.AsInt64())
+		}
+	}
+
+	assert.Equal(t, []int64{1, 1}, got[ConsumerMessages])
+	assert.Len(t, got[ConsumerDeliveryLatency], 2)
+	require.Len(t, got[ConsumerEndToEndLatency], 1, "messages without timestamp have no end-to-end latency")
+	assert.GreaterOrEqual(t, got[ConsumerEndToEndLatency][0], time.Second.Microseconds())
+	require.Len(t, got[ConsumerLag], 2)
+	for _, lag := range got[ConsumerLag] {
+		assert.GreaterOrEqual(t, lag, int64(0))
+	}
+	assert.Equal(t, int64(0), got[ConsumerLag][1], "the last message leaves no lag")
+}
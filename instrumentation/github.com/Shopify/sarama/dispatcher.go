@@ -17,6 +17,7 @@ package sarama
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/Shopify/sarama"
 
@@ -34,7 +35,8 @@ type consumerMessagesDispatcherWrapper struct {
 	d        consumerMessagesDispatcher
 	messages chan *sarama.ConsumerMessage
 
-	cfg config
+	cfg     config
+	metrics *consumerMetrics
 }
 
 func newConsumerMessagesDispatcherWrapper(d consumerMessagesDispatcher, cfg config) *consumerMessagesDispatcherWrapper {
@@ -42,6 +44,7 @@ func newConsumerMessagesDispatcherWrapper(d consumerMessagesDispatcher, cfg conf
 		d:        d,
 		messages: make(chan *sarama.ConsumerMessage),
 		cfg:      cfg,
+		metrics:  newConsumerMetrics(cfg.Meter),
 	}
 }
 
@@ -53,8 +56,13 @@ func (w *consumerMessagesDispatcherWrapper) Messages() <-chan *sarama.ConsumerMe
 
 func (w *consumerMessagesDispatcherWrapper) Run() {
 	msgs := w.d.Messages()
+	// Partition consumers and consumer group claims both report their high
+	// water mark, from which the lag is derived.
+	hwm, _ := w.d.(highWaterMarker)
 
 	for msg := range msgs {
+		received := time.Now()
+
 		// Extract a span context from message to link.
 		carrier := NewConsumerMessageCarrier(msg)
 		parentSpanContext := propagation.ExtractHTTP(context.Background(), w.cfg.Propagators, carrier)
@@ -81,6 +89,7 @@ func (w *consumerMessagesDispatcherWrapper) Run() {
 		// Send messages back to user.
 		w.messages <- msg
 
+		w.metrics.record(newCtx, msg, received, hwm)
 		span.End()
 	}
 	close(w.messages)
@@ -15,6 +15,13 @@
 // Package sarama provides functions to trace the Shopify/sarama package. (https://github.com/Shopify/sarama)
 //
 // The consumer's span will be created as a child of the producer's span.
+// The processing of a consumed message can be traced with StartProcessSpan,
+// whose span is a child of the consumer's span.
+//
+// Admin operations and the broker requests of a client can be traced with
+// WrapClusterAdmin and WrapClient. Offset commits are not traced: sarama
+// commits the offsets marked on a sarama.OffsetManager from a background
+// loop, which has no hook.
 //
 // Context propagation only works on Kafka versions higher than 0.11.0.0 which supports record headers.
 // (https://archive.apache.org/dist/kafka/0.11.0.0/RELEASE_NOTES.html)
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/standard"
+)
+
+// Consumer metrics
+const (
+	ConsumerMessages        = "messaging.kafka.consumer.messages"         // Messages consumed
+	ConsumerDeliveryLatency = "messaging.kafka.consumer.delivery_latency" // Time from a message being read from sarama to the application reading it from the wrapper, which grows when the application falls behind, microseconds
+	ConsumerEndToEndLatency = "messaging.kafka.consumer.e2e_latency"      // Time from the message timestamp to its delivery to the application, microseconds
+	ConsumerLag             = "messaging.kafka.consumer.lag"              // Messages remaining in the partition after the delivered one
+
+	ConsumerProcessingLatency = "messaging.kafka.consumer.processing_latency" // Time from StartProcessSpan or StartBatchProcessSpan to EndProcessSpan, microseconds
+)
+
+// Producer metrics
+const (
+	ProducerMessages   = "messaging.kafka.producer.messages"    // Messages sent
+	ProducerBytes      = "messaging.kafka.producer.bytes"       // Bytes of message keys and values sent
+	ProducerErrors     = "messaging.kafka.producer.errors"      // Messages that failed to be sent
+	ProducerAckLatency = "messaging.kafka.producer.ack_latency" // Time from a message being sent to its acknowledgement, microseconds
+)
+
+func handleErr(err error) {
+	if err != nil {
+		global.Handle(err)
+	}
+}
+
+// highWaterMarker is implemented by sarama.PartitionConsumer and
+// sarama.ConsumerGroupClaim.
+type highWaterMarker interface {
+	HighWaterMarkOffset() int64
+}
+
+// consumerMetrics holds the consumer instruments. A nil *consumerMetrics
+// records nothing.
+type consumerMetrics struct {
+	messages        metric.Int64Counter
+	deliveryLatency metric.Int64ValueRecorder
+	endToEndLatency metric.Int64ValueRecorder
+	lag             metric.Int64ValueRecorder
+}
+
+func newConsumerMetrics(meter metric.Meter) *consumerMetrics {
+	if meter.MeterImpl() == nil {
+		return nil
+	}
+
+	m := &consumerMetrics{}
+	var err error
+
+	m.messages, err = meter.NewInt64Counter(ConsumerMessages)
+	handleErr(err)
+
+	m.deliveryLatency, err = meter.NewInt64ValueRecorder(ConsumerDeliveryLatency)
+	handleErr(err)
+
+	m.endToEndLatency, err = meter.NewInt64ValueRecorder(ConsumerEndToEndLatency)
+	handleErr(err)
+
+	m.lag, err = meter.NewInt64ValueRecorder(ConsumerLag)
+	handleErr(err)
+
+	return m
+}
+
+// messageLabels returns the topic and partition labels of msg.
+func messageLabels(msg *sarama.ConsumerMessage) []kv.KeyValue {
+	return []kv.KeyValue{
+		standard.MessagingDestinationKey.String(msg.Topic),
+		kafkaPartitionKey.Int32(msg.Partition),
+	}
+}
+
+// record records the delivery of msg, read from sarama at received, to the
+// application. The lag is derived from hwm when it is not nil.
+func (m *consumerMetrics) record(ctx context.Context, msg *sarama.ConsumerMessage, received time.Time, hwm highWaterMarker) {
+	if m == nil {
+		return
+	}
+
+	now := time.Now()
+	labels := messageLabels(msg)
+	m.messages.Add(ctx, 1, labels...)
+	m.deliveryLatency.Record(ctx, now.Sub(received).Microseconds(), labels...)
+	if !msg.Timestamp.IsZero() {
+		m.endToEndLatency.Record(ctx, now.Sub(msg.Timestamp).Microseconds(), labels...)
+	}
+	if hwm != nil {
+		lag := hwm.HighWaterMarkOffset() - msg.Offset - 1
+		if lag < 0 {
+			lag = 0
+		}
+		m.lag.Record(ctx, lag, labels...)
+	}
+}
+
+// producerMetrics holds the producer instruments. A nil *producerMetrics
+// records nothing.
+type producerMetrics struct {
+	messages   metric.Int64Counter
+	bytes      metric.Int64Counter
+	errors     metric.Int64Counter
+	ackLatency metric.Int64ValueRecorder
+}
+
+func newProducerMetrics(meter metric.Meter) *producerMetrics {
+	if meter.MeterImpl() == nil {
+		return nil
+	}
+
+	m := &producerMetrics{}
+	var err error
+
+	m.messages, err = meter.NewInt64Counter(ProducerMessages)
+	handleErr(err)
+
+	m.bytes, err = meter.NewInt64Counter(ProducerBytes)
+	handleErr(err)
+
+	m.errors, err = meter.NewInt64Counter(ProducerErrors)
+	handleErr(err)
+
+	m.ackLatency, err = meter.NewInt64ValueRecorder(ProducerAckLatency)
+	handleErr(err)
+
+	return m
+}
+
+// messageSize returns the length of the key and value of msg.
+func messageSize(msg *sarama.ProducerMessage) int {
+	size := 0
+	if msg.Key != nil {
+		size += msg.Key.Length()
+	}
+	if msg.Value != nil {
+		size += msg.Value.Length()
+	}
+	return size
+}
+
+// errorType returns a low cardinality description of err: the message of
+// Kafka protocol errors, "producer closed" for messages left unacknowledged
+// at close and the Go type of any other error.
+func errorType(err error) string {
+	if kerr, ok := err.(sarama.KError); ok {
+		return kerr.Error()
+	}
+	if err == errProducerClosed {
+		return "producer closed"
+	}
+	return fmt.Sprintf("%T", err)
+}
+
+// record records the outcome of sending a message of size bytes to topic.
+// The acknowledgement latency is only recorded if acked is true.
+func (m *producerMetrics) record(ctx context.Context, topic string, size int, latency time.Duration, acked bool, err error) {
+	if m == nil {
+		return
+	}
+
+	labels := []kv.KeyValue{standard.MessagingDestinationKey.String(topic)}
+	if err != nil {
+		m.errors.Add(ctx, 1, append(labels, kafkaErrorTypeKey.String(errorType(err)))...)
+		return
+	}
+	m.messages.Add(ctx, 1, labels...)
+	m.bytes.Add(ctx, int64(size), labels...)
+	if acked {
+		m.ackLatency.Record(ctx, latency.Microseconds(), labels...)
+	}
+}
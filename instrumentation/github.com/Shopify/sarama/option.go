@@ -17,6 +17,7 @@ package sarama
 import (
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
 	otelpropagation "go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/trace"
 )
@@ -25,12 +26,22 @@ const (
 	defaultTracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama"
 
 	kafkaPartitionKey = kv.Key("messaging.kafka.partition")
+	kafkaErrorTypeKey = kv.Key("messaging.kafka.error_type")
+	kafkaBatchSizeKey = kv.Key("messaging.kafka.batch_size")
+
+	kafkaPartitionCountKey    = kv.Key("messaging.kafka.partition_count")
+	kafkaPartitionsTrimmedKey = kv.Key("messaging.kafka.partitions_trimmed")
+	kafkaConsumerGroupKey     = kv.Key("messaging.kafka.consumer_group")
+	kafkaResourceKey          = kv.Key("messaging.kafka.resource")
 )
 
 type config struct {
 	ServiceName string
 	Tracer      trace.Tracer
 	Propagators otelpropagation.Propagators
+	Meter       metric.Meter
+
+	ProcessSpanLinks bool
 }
 
 // newConfig returns a config with all Options set.
@@ -66,3 +77,20 @@ func WithPropagators(propagators otelpropagation.Propagators) Option {
 		cfg.Propagators = propagators
 	}
 }
+
+// WithMeter enables metrics, recorded with the provided meter. If this option
+// isn't specified no metrics are recorded.
+func WithMeter(meter metric.Meter) Option {
+	return func(cfg *config) {
+		cfg.Meter = meter
+	}
+}
+
+// WithProcessSpanLinks makes the spans started by StartProcessSpan children
+// of the span held by the context, such as the span of a batch, and links
+// them to the span context of their message instead of using it as parent.
+func WithProcessSpanLinks() Option {
+	return func(cfg *config) {
+		cfg.ProcessSpanLinks = true
+	}
+}
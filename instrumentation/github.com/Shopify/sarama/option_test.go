@@ -62,6 +62,32 @@ func TestNewConfig(t *testing.T) {
 				Propagators: nil,
 			},
 		},
+		{
+			name:        "with meter",
+			serviceName: serviceName,
+			opts: []Option{
+				WithMeter(global.Meter("new")),
+			},
+			expected: config{
+				ServiceName: serviceName,
+				Tracer:      global.Tracer(defaultTracerName),
+				Propagators: global.Propagators(),
+				Meter:       global.Meter("new"),
+			},
+		},
+		{
+			name:        "with process span links",
+			serviceName: serviceName,
+			opts: []Option{
+				WithProcessSpanLinks(),
+			},
+			expected: config{
+				ServiceName:      serviceName,
+				Tracer:           global.Tracer(defaultTracerName),
+				Propagators:      global.Propagators(),
+				ProcessSpanLinks: true,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// StartProcessSpan starts a span covering the processing of msg by the
+// application. The returned context holds the span and should be used for
+// any work done for msg. The span must be ended with EndProcessSpan.
+//
+// The span is a child of the span context carried by msg, which is the
+// receive span of a wrapped consumer, or the producer span otherwise. If the
+// WithProcessSpanLinks option is used, the span is instead a child of the
+// span held by ctx, if any, and is linked to the span context of msg.
+func StartProcessSpan(ctx context.Context, serviceName string, msg *sarama.ConsumerMessage, opts ...Option) (context.Context, trace.Span) {
+	cfg := newConfig(serviceName, opts...)
+
+	msgCtx := propagation.ExtractHTTP(context.Background(), cfg.Propagators, NewConsumerMessageCarrier(msg))
+	msgSpanContext := trace.RemoteSpanContextFromContext(msgCtx)
+
+	startOpts := []trace.StartOption{
+		trace.WithAttributes(
+			standard.ServiceNameKey.String(cfg.ServiceName),
+			standard.MessagingSystemKey.String("kafka"),
+			standard.MessagingDestinationKindKeyTopic,
+			standard.MessagingDestinationKey.String(msg.Topic),
+			standard.MessagingOperationProcess,
+			standard.MessagingMessageIDKey.String(strconv.FormatInt(msg.Offset, 10)),
+			kafkaPartitionKey.Int32(msg.Partition),
+		),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	}
+	if cfg.ProcessSpanLinks {
+		if msgSpanContext.IsValid() {
+			startOpts = append(startOpts, trace.LinkedTo(msgSpanContext))
+		}
+	} else if msgSpanContext.IsValid() {
+		// A span held by ctx would take precedence over the remote span
+		// context, so it is replaced by a span with an invalid context.
+		ctx = trace.ContextWithSpan(ctx, trace.NoopSpan{})
+		ctx = trace.ContextWithRemoteSpanContext(ctx, msgSpanContext)
+	}
+
+	return startProcessSpan(ctx, cfg, "kafka.process", messageLabels(msg), startOpts)
+}
+
+// StartBatchProcessSpan starts a span covering the processing of msgs by the
+// application as a batch. As a span has a single parent, the span is a child
+// of the span held by ctx, if any, and is linked to the span context carried
+// by each message. The span must be ended with EndProcessSpan.
+func StartBatchProcessSpan(ctx context.Context, serviceName string, msgs []*sarama.ConsumerMessage, opts ...Option) (context.Context, trace.Span) {
+	cfg := newConfig(serviceName, opts...)
+
+	attrs := []kv.KeyValue{
+		standard.ServiceNameKey.String(cfg.ServiceName),
+		standard.MessagingSystemKey.String("kafka"),
+		standard.MessagingOperationProcess,
+		kafkaBatchSizeKey.Int(len(msgs)),
+	}
+	// Only set the destination if it is shared by all the messages.
+	var labels []kv.KeyValue
+	if len(msgs) > 0 {
+		topic := msgs[0].Topic
+		for _, msg := range msgs[1:] {
+			if msg.Topic != topic {
+				topic = ""
+				break
+			}
+		}
+		if topic != "" {
+			attrs = append(attrs,
+				standard.MessagingDestinationKindKeyTopic,
+				standard.MessagingDestinationKey.String(topic),
+			)
+			labels = append(labels, standard.MessagingDestinationKey.String(topic))
+		}
+	}
+	startOpts := []trace.StartOption{
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	}
+	for _, msg := range msgs {
+		msgCtx := propagation.ExtractHTTP(context.Background(), cfg.Propagators, NewConsumerMessageCarrier(msg))
+		if sc := trace.RemoteSpanContextFromContext(msgCtx); sc.IsValid() {
+			startOpts = append(startOpts, trace.LinkedTo(sc,
+				standard.MessagingMessageIDKey.String(strconv.FormatInt(msg.Offset, 10)),
+				kafkaPartitionKey.Int32(msg.Partition),
+			))
+		}
+	}
+
+	return startProcessSpan(ctx, cfg, "kafka.process_batch", labels, startOpts)
+}
+
+// processSpan is a process span started with a meter, which records the
+// processing latency when it is ended by EndProcessSpan.
+type processSpan struct {
+	trace.Span
+	latency metric.Int64ValueRecorder
+	labels  []kv.KeyValue
+	start   time.Time
+}
+
+// startProcessSpan starts a process span, with the labels of its processing
+// latency if cfg has a meter.
+func startProcessSpan(ctx context.Context, cfg config, name string, labels []kv.KeyValue, opts []trace.StartOption) (context.Context, trace.Span) {
+	ctx, span := cfg.Tracer.Start(ctx, name, opts...)
+	if cfg.Meter.MeterImpl() == nil {
+		return ctx, span
+	}
+
+	latency, err := cfg.Meter.NewInt64ValueRecorder(ConsumerProcessingLatency)
+	handleErr(err)
+	ps := &processSpan{Span: span, latency: latency, labels: labels, start: time.Now()}
+	return trace.ContextWithSpan(ctx, ps), ps
+}
+
+// EndProcessSpan ends a span started with StartProcessSpan or
+// StartBatchProcessSpan, recording err as its status if it is not nil. If the
+// span was started with the WithMeter option, the processing latency is
+// recorded.
+func EndProcessSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Internal, err.Error())
+	}
+	if ps, ok := span.(*processSpan); ok {
+		ps.latency.Record(context.Background(), time.Since(ps.start).Microseconds(), ps.labels...)
+	}
+	span.End()
+}
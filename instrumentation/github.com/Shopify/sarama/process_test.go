@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
+	mocktracer "go.opentelemetry.io/contrib/internal/trace"
+)
+
+// consumeMessages returns n messages received from a wrapped partition
+// consumer, along with their receive spans.
+func consumeMessages(t *testing.T, mt *mocktracer.Tracer, n int) ([]*sarama.ConsumerMessage, []*mocktracer.Span) {
+	consumer := mocks.NewConsumer(t, sarama.NewConfig())
+	mockPartitionConsumer := consumer.ExpectConsumePartition(topic, 0, 0)
+	partitionConsumer, err := consumer.ConsumePartition(topic, 0, 0)
+	require.NoError(t, err)
+	partitionConsumer = WrapPartitionConsumer(serviceName, partitionConsumer, WithTracer(mt))
+
+	msgs := make([]*sarama.ConsumerMessage, n)
+	for i := range msgs {
+		mockPartitionConsumer.YieldMessage(&sarama.ConsumerMessage{Topic: topic, Key: []byte("foo")})
+		msgs[i] = <-partitionConsumer.Messages()
+	}
+	require.NoError(t, partitionConsumer.Close())
+	// Wait for the channel to be closed
+	<-partitionConsumer.Messages()
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, n)
+	return msgs, spans
+}
+
+func TestStartProcessSpan(t *testing.T) {
+	mt := mocktracer.NewTracer("kafka")
+	msgs, receiveSpans := consumeMessages(t, mt, 1)
+
+	ctx, parent := mt.Start(context.Background(), "poll")
+	_, span := StartProcessSpan(ctx, serviceName, msgs[0], WithTracer(mt))
+	EndProcessSpan(span, errors.New("test"))
+	parent.End()
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 2)
+	process := spans[0]
+	assert.Equal(t, "kafka.process", process.Name)
+	assert.Equal(t, trace.SpanKindConsumer, process.Kind)
+	assert.Equal(t, receiveSpans[0].SpanContext().SpanID, process.ParentSpanID,
+		"the process span must be a child of the receive span")
+	assert.Equal(t, standard.MessagingOperationProcess.Value, process.Attributes[standard.MessagingOperationKey])
+	assert.Equal(t, kv.StringValue(topic), process.Attributes[standard.MessagingDestinationKey])
+	assert.Equal(t, codes.Internal, process.Status)
+	assert.Equal(t, "test", process.StatusMessage)
+}
+
+func TestStartProcessSpanLinks(t *testing.T) {
+	mt := mocktracer.NewTracer("kafka")
+	msgs, receiveSpans := consumeMessages(t, mt, 2)
+
+	ctx, batch := StartBatchProcessSpan(context.Background(), serviceName, msgs, WithTracer(mt))
+	for _, msg := range msgs {
+		_, span := StartProcessSpan(ctx, serviceName, msg, WithTracer(mt), WithProcessSpanLinks())
+		EndProcessSpan(span, nil)
+	}
+	EndProcessSpan(batch, nil)
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 3)
+
+	batchSpan := spans[2]
+	assert.Equal(t, "kafka.process_batch", batchSpan.Name)
+	assert.Equal(t, kv.IntValue(2), batchSpan.Attributes[kafkaBatchSizeKey])
+	assert.Equal(t, kv.StringValue(topic), batchSpan.Attributes[standard.MessagingDestinationKey])
+	assert.Len(t, batchSpan.Links, 2)
+
+	for i, span := range spans[:2] {
+		assert.Equal(t, batchSpan.SpanContext().SpanID, span.ParentSpanID)
+		assert.Contains(t, span.Links, receiveSpans[i].SpanContext())
+		assert.Contains(t, batchSpan.Links, receiveSpans[i].SpanContext())
+	}
+}
+
+func TestProcessSpanMetrics(t *testing.T) {
+	mt := mocktracer.NewTracer("kafka")
+	msgs, _ := consumeMessages(t, mt, 2)
+	meterimpl, meter := mockmeter.NewMeter()
+
+	ctx, batch := StartBatchProcessSpan(context.Background(), serviceName, msgs, WithTracer(mt), WithMeter(meter))
+	for _, msg := range msgs {
+		_, span := StartProcessSpan(ctx, serviceName, msg, WithTracer(mt), WithMeter(meter), WithProcessSpanLinks())
+		time.Sleep(time.Millisecond)
+		EndProcessSpan(span, nil)
+	}
+	EndProcessSpan(batch, nil)
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 3)
+	for _, span := range spans[:2] {
+		assert.Equal(t, spans[2].SpanContext().SpanID, span.ParentSpanID)
+	}
+
+	require.Len(t, meterimpl.MeasurementBatches, 3)
+	for i, batch := range meterimpl.MeasurementBatches {
+		labels := []kv.KeyValue{standard.MessagingDestinationKey.String(topic)}
+		if i < 2 {
+			labels = append(labels, kafkaPartitionKey.Int32(0))
+		}
+		assert.Equal(t, labels, batch.Labels)
+		require.Len(t, batch.Measurements, 1)
+		assert.Equal(t, ConsumerProcessingLatency, batch.Measurements[0].Instrument.Descriptor().Name())
+		assert.GreaterOrEqual(t, batch.Measurements[0].Number.AsInt64(), time.Millisecond.Microseconds())
+	}
+}
@@ -16,7 +16,9 @@ package sarama
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"google.golang.org/grpc/codes"
@@ -31,11 +33,12 @@ type syncProducer struct {
 	sarama.SyncProducer
 	cfg          config
 	saramaConfig *sarama.Config
+	metrics      *producerMetrics
 }
 
 // SendMessage calls sarama.SyncProducer.SendMessage and traces the request.
 func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
-	span := startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
+	span := startProducerSpan(context.Background(), p.cfg, p.saramaConfig.Version, p.metrics, msg)
 	partition, offset, err = p.SyncProducer.SendMessage(msg)
 	finishProducerSpan(span, partition, offset, err)
 	return partition, offset, err
@@ -44,18 +47,47 @@ func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32
 // SendMessages calls sarama.SyncProducer.SendMessages and traces the requests.
 func (p *syncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
 	// Although there's only one call made to the SyncProducer, the messages are
-	// treated individually, so we create a span for each one
-	spans := make([]trace.Span, len(msgs))
+	// treated individually, so we create a span for each one, linked to a span
+	// covering the whole batch. Messages that carry no span context are
+	// children of the batch span.
+	ctx, batchSpan := p.cfg.Tracer.Start(context.Background(), "kafka.produce_batch",
+		trace.WithAttributes(
+			standard.ServiceNameKey.String(p.cfg.ServiceName),
+			standard.MessagingSystemKey.String("kafka"),
+			kafkaBatchSizeKey.Int(len(msgs)),
+		),
+		trace.WithSpanKind(trace.SpanKindProducer),
+	)
+	spans := make([]producerSpan, len(msgs))
 	for i, msg := range msgs {
-		spans[i] = startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
+		spans[i] = startProducerSpan(ctx, p.cfg, p.saramaConfig.Version, p.metrics, msg)
 	}
 	err := p.SyncProducer.SendMessages(msgs)
 	for i, span := range spans {
-		finishProducerSpan(span, msgs[i].Partition, msgs[i].Offset, err)
+		finishProducerSpan(span, msgs[i].Partition, msgs[i].Offset, messageError(err, msgs[i]))
 	}
+	if err != nil {
+		batchSpan.SetStatus(codes.Internal, err.Error())
+	}
+	batchSpan.End()
 	return err
 }
 
+// messageError returns the error of msg within the error returned by
+// sarama.SyncProducer.SendMessages.
+func messageError(err error, msg *sarama.ProducerMessage) error {
+	errs, ok := err.(sarama.ProducerErrors)
+	if !ok {
+		return err
+	}
+	for _, perr := range errs {
+		if perr.Msg == msg {
+			return perr.Err
+		}
+	}
+	return nil
+}
+
 // WrapSyncProducer wraps a sarama.SyncProducer so that all produced messages
 // are traced.
 func WrapSyncProducer(serviceName string, saramaConfig *sarama.Config, producer sarama.SyncProducer, opts ...Option) sarama.SyncProducer {
@@ -68,6 +100,7 @@ func WrapSyncProducer(serviceName string, saramaConfig *sarama.Config, producer
 		SyncProducer: producer,
 		cfg:          cfg,
 		saramaConfig: saramaConfig,
+		metrics:      newProducerMetrics(cfg.Meter),
 	}
 }
 
@@ -120,8 +153,12 @@ func (p *asyncProducer) Close() error {
 	return <-p.closeErr
 }
 
+// errProducerClosed finishes the spans of the messages that were neither
+// acknowledged nor failed when the async producer was closed.
+var errProducerClosed = errors.New("producer closed before the message was acknowledged")
+
 type producerMessageContext struct {
-	span           trace.Span
+	span           producerSpan
 	metadataBackup interface{}
 }
 
@@ -144,19 +181,21 @@ func WrapAsyncProducer(serviceName string, saramaConfig *sarama.Config, p sarama
 		errors:        make(chan *sarama.ProducerError),
 		closeErr:      make(chan error),
 	}
+	metrics := newProducerMetrics(cfg.Meter)
 	go func() {
 		producerMessageContexts := make(map[interface{}]producerMessageContext)
-		// Clear all spans.
+		defer close(wrapped.successes)
+		defer close(wrapped.errors)
+		// Clear all spans, before the channels are closed.
 		// Sarama will consume all the successes and errors by itself while closing,
 		// so our `Successes()` and `Errors()` may get nothing and those remaining spans
 		// cannot be closed.
 		defer func() {
 			for _, mc := range producerMessageContexts {
-				finishProducerSpan(mc.span, 0, 0, nil)
+				mc.span.acked = false
+				finishProducerSpan(mc.span, 0, 0, errProducerClosed)
 			}
 		}()
-		defer close(wrapped.successes)
-		defer close(wrapped.errors)
 		for {
 			select {
 			case msg := <-wrapped.input:
@@ -176,7 +215,7 @@ func WrapAsyncProducer(serviceName string, saramaConfig *sarama.Config, p sarama
 					continue
 				}
 
-				span := startProducerSpan(cfg, saramaConfig.Version, msg)
+				span := startProducerSpan(context.Background(), cfg, saramaConfig.Version, metrics, msg)
 
 				// Create message context, backend message metadata
 				mc := producerMessageContext{
@@ -194,6 +233,7 @@ func WrapAsyncProducer(serviceName string, saramaConfig *sarama.Config, p sarama
 					// If returning successes isn't enabled, we just finish the
 					// span right away because there's no way to know when it will
 					// be done.
+					span.acked = false
 					finishProducerSpan(span, msg.Partition, msg.Offset, nil)
 				}
 			case msg, ok := <-p.Successes():
@@ -227,8 +267,24 @@ func WrapAsyncProducer(serviceName string, saramaConfig *sarama.Config, p sarama
 	return wrapped
 }
 
-func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.ProducerMessage) trace.Span {
-	// If there's a span context in the message, use that as the parent context.
+// producerSpan is the span of a produced message, along with what is needed
+// to record its metrics once it is acknowledged.
+type producerSpan struct {
+	trace.Span
+	metrics *producerMetrics
+	topic   string
+	size    int
+	start   time.Time
+	// acked is false if the span is finished without waiting for the
+	// acknowledgement of the message, in which case the latency is not
+	// recorded.
+	acked bool
+}
+
+// startProducerSpan starts the span of msg, as a child of the span context
+// carried by msg. If batch holds the span of a batch of messages, the span is
+// linked to it, or is its child if msg carries no span context.
+func startProducerSpan(batch context.Context, cfg config, version sarama.KafkaVersion, metrics *producerMetrics, msg *sarama.ProducerMessage) producerSpan {
 	carrier := NewProducerMessageCarrier(msg)
 	ctx := propagation.ExtractHTTP(context.Background(), cfg.Propagators, carrier)
 
@@ -243,6 +299,13 @@ func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.Prod
 		trace.WithAttributes(attrs...),
 		trace.WithSpanKind(trace.SpanKindProducer),
 	}
+	if batchSpan := trace.SpanFromContext(batch).SpanContext(); batchSpan.IsValid() {
+		if trace.RemoteSpanContextFromContext(ctx).IsValid() {
+			opts = append(opts, trace.LinkedTo(batchSpan))
+		} else {
+			ctx = batch
+		}
+	}
 	ctx, span := cfg.Tracer.Start(ctx, "kafka.produce", opts...)
 
 	if version.IsAtLeast(sarama.V0_11_0_0) {
@@ -250,10 +313,19 @@ func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.Prod
 		propagation.InjectHTTP(ctx, cfg.Propagators, carrier)
 	}
 
-	return span
+	return producerSpan{
+		Span:    span,
+		metrics: metrics,
+		topic:   msg.Topic,
+		size:    messageSize(msg),
+		start:   time.Now(),
+		acked:   true,
+	}
 }
 
-func finishProducerSpan(span trace.Span, partition int32, offset int64, err error) {
+func finishProducerSpan(span producerSpan, partition int32, offset int64, err error) {
+	span.metrics.record(context.Background(), span.topic, span.size, time.Since(span.start), span.acked, err)
+
 	span.SetAttributes(
 		standard.MessagingMessageIDKey.String(strconv.FormatInt(offset, 10)),
 		kafkaPartitionKey.Int32(partition),
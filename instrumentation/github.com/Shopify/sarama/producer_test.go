@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/Shopify/sarama/mocks"
@@ -30,6 +31,7 @@ import (
 	"go.opentelemetry.io/otel/api/standard"
 	"go.opentelemetry.io/otel/api/trace"
 
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
 	mocktracer "go.opentelemetry.io/contrib/internal/trace"
 )
 
@@ -55,6 +57,7 @@ func TestWrapSyncProducer(t *testing.T) {
 	expectedList := []struct {
 		kvList       []kv.KeyValue
 		parentSpanID trace.SpanID
+		batched      bool
 		kind         trace.SpanKind
 	}{
 		{
@@ -91,7 +94,8 @@ func TestWrapSyncProducer(t *testing.T) {
 				//standard.MessagingMessageIDKey.String("3"),
 				kafkaPartitionKey.Int32(0),
 			},
-			kind: trace.SpanKindProducer,
+			batched: true,
+			kind:    trace.SpanKindProducer,
 		},
 		{
 			kvList: []kv.KeyValue{
@@ -102,7 +106,8 @@ func TestWrapSyncProducer(t *testing.T) {
 				//standard.MessagingMessageIDKey.String("4"),
 				kafkaPartitionKey.Int32(0),
 			},
-			kind: trace.SpanKindProducer,
+			batched: true,
+			kind:    trace.SpanKindProducer,
 		},
 	}
 	for i := 0; i < len(expectedList); i++ {
@@ -124,12 +129,22 @@ func TestWrapSyncProducer(t *testing.T) {
 	require.NoError(t, syncProducer.SendMessages(msgList[2:]))
 
 	spanList := mt.EndedSpans()
+	require.Len(t, spanList, len(expectedList)+1)
+
+	// Check batch span
+	batchSpan := spanList[len(expectedList)]
+	assert.Equal(t, "kafka.produce_batch", batchSpan.Name)
+	assert.Equal(t, kv.IntValue(2), batchSpan.Attributes[kafkaBatchSizeKey])
+
 	for i, expected := range expectedList {
 		span := spanList[i]
 		msg := msgList[i]
 
 		// Check span
 		assert.True(t, span.SpanContext().IsValid())
+		if expected.batched {
+			expected.parentSpanID = batchSpan.SpanContext().SpanID
+		}
 		assert.Equal(t, expected.parentSpanID, span.ParentSpanID)
 		assert.Equal(t, "kafka.produce", span.Name)
 		assert.Equal(t, expected.kind, span.Kind)
@@ -330,6 +345,162 @@ func TestWrapAsyncProducerError(t *testing.T) {
 	assert.Equal(t, "test", span.StatusMessage)
 }
 
+func TestWrapSyncProducerBatchLinks(t *testing.T) {
+	mt := mocktracer.NewTracer("kafka")
+	cfg := newSaramaConfig()
+	mockSyncProducer := mocks.NewSyncProducer(t, cfg)
+	syncProducer := WrapSyncProducer(serviceName, cfg, mockSyncProducer, WithTracer(mt))
+
+	// Create message with span context
+	ctx, _ := mt.Start(context.Background(), "")
+	messageWithSpanContext := sarama.ProducerMessage{Topic: topic, Key: sarama.StringEncoder("foo")}
+	propagation.InjectHTTP(ctx, propagators, NewProducerMessageCarrier(&messageWithSpanContext))
+	mt.EndedSpans()
+
+	mockSyncProducer.ExpectSendMessageAndSucceed()
+	mockSyncProducer.ExpectSendMessageAndSucceed()
+	require.NoError(t, syncProducer.SendMessages([]*sarama.ProducerMessage{
+		&messageWithSpanContext,
+		{Topic: topic, Key: sarama.StringEncoder("foo2")},
+	}))
+
+	spanList := mt.EndedSpans()
+	require.Len(t, spanList, 3)
+	batchSpan := spanList[2]
+	upstream := trace.SpanFromContext(ctx).SpanContext()
+
+	assert.Equal(t, upstream.SpanID, spanList[0].ParentSpanID,
+		"the span context of the message must stay the parent")
+	assert.Equal(t, upstream.TraceID, spanList[0].SpanContext().TraceID)
+	assert.Contains(t, spanList[0].Links, batchSpan.SpanContext(),
+		"the batch span must be linked")
+
+	assert.Equal(t, batchSpan.SpanContext().SpanID, spanList[1].ParentSpanID)
+	assert.Empty(t, spanList[1].Links)
+}
+
+func TestWrapProducerMetrics(t *testing.T) {
+	collect := func(meterimpl *mockmeter.MeterImpl) (map[string][]int64, []string) {
+		got := map[string][]int64{}
+		var errorTypes []string
+		for _, batch := range meterimpl.MeasurementBatches {
+			assert.Equal(t, standard.MessagingDestinationKey.String(topic), batch.Labels[0])
+			for _, m := range batch.Measurements {
+				name := m.Instrument.Descriptor().Name()
+				got[name] = append(got[name], m.Number.AsInt64())
+				if name == ProducerErrors {
+					errorTypes = append(errorTypes, batch.Labels[1].Value.AsString())
+				}
+			}
+		}
+		return got, errorTypes
+	}
+
+	t.Run("sync", func(t *testing.T) {
+		meterimpl, meter := mockmeter.NewMeter()
+		cfg := newSaramaConfig()
+		mockSyncProducer := mocks.NewSyncProducer(t, cfg)
+		syncProducer := WrapSyncProducer(serviceName, cfg, mockSyncProducer,
+			WithTracer(mocktracer.NewTracer("kafka")), WithMeter(meter))
+
+		mockSyncProducer.ExpectSendMessageAndSucceed()
+		_, _, err := syncProducer.SendMessage(&sarama.ProducerMessage{Topic: topic, Key: sarama.StringEncoder("foo"), Value: sarama.StringEncoder("bar")})
+		require.NoError(t, err)
+		mockSyncProducer.ExpectSendMessageAndFail(sarama.ErrMessageSizeTooLarge)
+		_, _, err = syncProducer.SendMessage(&sarama.ProducerMessage{Topic: topic, Value: sarama.StringEncoder("baz")})
+		require.Error(t, err)
+		require.NoError(t, syncProducer.Close())
+
+		got, errorTypes := collect(meterimpl)
+		assert.Equal(t, []int64{1}, got[ProducerMessages])
+		assert.Equal(t, []int64{6}, got[ProducerBytes])
+		assert.Equal(t, []int64{1}, got[ProducerErrors])
+		assert.Equal(t, []string{sarama.ErrMessageSizeTooLarge.Error()}, errorTypes)
+		assert.Len(t, got[ProducerAckLatency], 1)
+	})
+
+	t.Run("async", func(t *testing.T) {
+		meterimpl, meter := mockmeter.NewMeter()
+		cfg := newSaramaConfig()
+		cfg.Producer.Return.Successes = true
+		mockAsyncProducer := mocks.NewAsyncProducer(t, cfg)
+		ap := WrapAsyncProducer(serviceName, cfg, mockAsyncProducer,
+			WithTracer(mocktracer.NewTracer("kafka")), WithMeter(meter))
+
+		mockAsyncProducer.ExpectInputAndSucceed()
+		ap.Input() <- &sarama.ProducerMessage{Topic: topic, Key: sarama.StringEncoder("foo")}
+		<-ap.Successes()
+		mockAsyncProducer.ExpectInputAndFail(errors.New("test"))
+		ap.Input() <- &sarama.ProducerMessage{Topic: topic, Key: sarama.StringEncoder("foo2")}
+		<-ap.Errors()
+		require.NoError(t, ap.Close())
+
+		got, errorTypes := collect(meterimpl)
+		assert.Equal(t, []int64{1}, got[ProducerMessages])
+		assert.Equal(t, []int64{3}, got[ProducerBytes])
+		assert.Equal(t, []string{"*errors.errorString"}, errorTypes)
+		require.Len(t, got[ProducerAckLatency], 1)
+		assert.Less(t, got[ProducerAckLatency][0], time.Minute.Microseconds())
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		meterimpl, meter := mockmeter.NewMeter()
+		mt := mocktracer.NewTracer("kafka")
+		cfg := newSaramaConfig()
+		cfg.Producer.Return.Successes = true
+		ap := WrapAsyncProducer(serviceName, cfg, newUnackedProducer(),
+			WithTracer(mt), WithMeter(meter))
+
+		ap.Input() <- &sarama.ProducerMessage{Topic: topic, Key: sarama.StringEncoder("foo")}
+		require.NoError(t, ap.Close())
+		for range ap.Errors() {
+		}
+
+		got, errorTypes := collect(meterimpl)
+		assert.Empty(t, got[ProducerMessages], "unacknowledged messages are not successes")
+		assert.Empty(t, got[ProducerAckLatency])
+		assert.Equal(t, []string{"producer closed"}, errorTypes)
+
+		spans := mt.EndedSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Internal, spans[0].Status)
+		assert.Equal(t, errProducerClosed.Error(), spans[0].StatusMessage)
+	})
+}
+
+// unackedProducer is an async producer that never acknowledges messages.
+type unackedProducer struct {
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newUnackedProducer() *unackedProducer {
+	p := &unackedProducer{
+		input:     make(chan *sarama.ProducerMessage),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+	go func() {
+		for range p.input {
+		}
+	}()
+	return p
+}
+
+func (p *unackedProducer) AsyncClose() { _ = p.Close() }
+
+func (p *unackedProducer) Close() error {
+	close(p.input)
+	close(p.successes)
+	close(p.errors)
+	return nil
+}
+
+func (p *unackedProducer) Input() chan<- *sarama.ProducerMessage     { return p.input }
+func (p *unackedProducer) Successes() <-chan *sarama.ProducerMessage { return p.successes }
+func (p *unackedProducer) Errors() <-chan *sarama.ProducerError      { return p.errors }
+
 func newSaramaConfig() *sarama.Config {
 	cfg := sarama.NewConfig()
 	cfg.Version = sarama.V0_11_0_0
@@ -23,6 +23,9 @@ import (
 type Config struct {
 	Tracer      oteltrace.Tracer
 	Propagators otelpropagation.Propagators
+
+	RecoverPanics bool
+	Repanic       bool
 }
 
 // Option specifies instrumentation configuration options.
@@ -46,3 +49,14 @@ func WithPropagators(propagators otelpropagation.Propagators) Option {
 		cfg.Propagators = propagators
 	}
 }
+
+// WithPanicRecovery makes the filter recover from panics in the route
+// function and the filters after it, and record them as exception events.
+// If repanic is true the panic is raised again, to be handled by the
+// container, otherwise status 500 is written unless a response was written.
+func WithPanicRecovery(repanic bool) Option {
+	return func(cfg *Config) {
+		cfg.RecoverPanics = true
+		cfg.Repanic = repanic
+	}
+}
@@ -15,8 +15,16 @@
 package restful
 
 import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"runtime/debug"
+
 	"github.com/emicklei/go-restful/v3"
 
+	"go.opentelemetry.io/contrib/internal/panics"
+
 	otelglobal "go.opentelemetry.io/otel/api/global"
 	otelpropagation "go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/standard"
@@ -59,6 +67,22 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 		ctx, span := cfg.Tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
+		if cfg.RecoverPanics {
+			tracker := &writeTracker{ResponseWriter: resp.ResponseWriter}
+			resp.ResponseWriter = tracker
+			defer func() {
+				if p := recover(); p != nil {
+					if !cfg.Repanic && !tracker.written {
+						resp.WriteHeader(http.StatusInternalServerError)
+					}
+					panics.RecordHTTP(ctx, span, p, debug.Stack())
+					if cfg.Repanic {
+						panic(p)
+					}
+				}
+			}()
+		}
+
 		// pass the span through the request context
 		req.Request = req.Request.WithContext(ctx)
 
@@ -70,3 +94,48 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 		span.SetStatus(spanStatus, spanMessage)
 	}
 }
+
+// writeTracker records whether a response was written. It exposes the
+// interfaces that restful.Response asserts on its writer, and passes
+// http.Hijacker and http.Pusher through to the wrapped writer.
+type writeTracker struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *writeTracker) WriteHeader(statusCode int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *writeTracker) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *writeTracker) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *writeTracker) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify() //nolint:staticcheck
+}
+
+func (w *writeTracker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not implement http.Hijacker")
+	}
+	w.written = true
+	return h.Hijack()
+}
+
+func (w *writeTracker) Push(target string, opts *http.PushOptions) error {
+	p, ok := w.ResponseWriter.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return p.Push(target, opts)
+}
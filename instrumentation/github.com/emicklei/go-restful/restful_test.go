@@ -259,3 +259,123 @@ func TestMultiFilters(t *testing.T) {
 	w = httptest.NewRecorder()
 	container.ServeHTTP(w, r)
 }
+
+func TestPanicRecovery(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {
+		panic("oh no")
+	}
+	ws := &restful.WebService{}
+	ws.Route(ws.GET("/panic").To(handlerFunc))
+	container := restful.NewContainer()
+	container.Filter(restfultrace.OTelFilter("foobar",
+		restfultrace.WithTracer(tracer), restfultrace.WithPanicRecovery(false)))
+	container.Add(ws)
+
+	w := httptest.NewRecorder()
+	container.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, otelkv.IntValue(http.StatusInternalServerError), span.Attributes["http.status_code"])
+	assert.Equal(t, "oh no", span.StatusMessage)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+	assert.Equal(t, otelkv.StringValue("oh no"), span.Events[0].Attributes["exception.message"])
+}
+
+func TestPanicRecoveryAfterWrite(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {
+		resp.WriteHeader(http.StatusAccepted)
+		panic("oh no")
+	}
+	ws := &restful.WebService{}
+	ws.Route(ws.GET("/panic").To(handlerFunc))
+	container := restful.NewContainer()
+	container.Filter(restfultrace.OTelFilter("foobar",
+		restfultrace.WithTracer(tracer), restfultrace.WithPanicRecovery(false)))
+	container.Add(ws)
+
+	w := &headerCounter{ResponseRecorder: httptest.NewRecorder()}
+	container.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+	assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+	assert.Equal(t, 1, w.headers, "no 500 is written after the response")
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "oh no", spans[0].StatusMessage)
+}
+
+func TestPanicRecoveryRepanic(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {
+		panic("oh no")
+	}
+	ws := &restful.WebService{}
+	ws.Route(ws.GET("/panic").To(handlerFunc))
+	container := restful.NewContainer()
+	container.DoNotRecover(true)
+	container.Filter(restfultrace.OTelFilter("foobar",
+		restfultrace.WithTracer(tracer), restfultrace.WithPanicRecovery(true)))
+	container.Add(ws)
+
+	assert.PanicsWithValue(t, "oh no", func() {
+		container.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", nil))
+	})
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "oh no", span.StatusMessage)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+}
+
+func TestResponseWriterInterfaces(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {
+		pusher, ok := resp.ResponseWriter.(http.Pusher)
+		require.True(t, ok, "http.Pusher is exposed")
+		assert.Equal(t, http.ErrNotSupported, pusher.Push("/style.css", nil))
+
+		hijacker, ok := resp.ResponseWriter.(http.Hijacker)
+		require.True(t, ok, "http.Hijacker is exposed")
+		conn, rw, err := hijacker.Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 204 No Content\r\nConnection: close\r\n\r\n")
+		require.NoError(t, rw.Flush())
+	}
+	ws := &restful.WebService{}
+	ws.Route(ws.GET("/hijack").To(handlerFunc))
+	container := restful.NewContainer()
+	container.Filter(restfultrace.OTelFilter("foobar",
+		restfultrace.WithTracer(tracer), restfultrace.WithPanicRecovery(false)))
+	container.Add(ws)
+
+	srv := httptest.NewServer(container)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/hijack")
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+// headerCounter counts the calls to WriteHeader.
+type headerCounter struct {
+	*httptest.ResponseRecorder
+	headers int
+}
+
+func (w *headerCounter) WriteHeader(statusCode int) {
+	w.headers++
+	w.ResponseRecorder.WriteHeader(statusCode)
+}
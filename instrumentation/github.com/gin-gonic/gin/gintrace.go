@@ -18,10 +18,14 @@ package gin
 
 import (
 	"fmt"
+	"net/http"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc/codes"
 
+	"go.opentelemetry.io/contrib/internal/panics"
+
 	otelglobal "go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/kv"
 	otelpropagation "go.opentelemetry.io/otel/api/propagation"
@@ -68,6 +72,20 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		ctx, span := cfg.Tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
+		if cfg.RecoverPanics {
+			defer func() {
+				if p := recover(); p != nil {
+					if !cfg.Repanic && !c.Writer.Written() {
+						c.AbortWithStatus(http.StatusInternalServerError)
+					}
+					panics.RecordHTTP(ctx, span, p, debug.Stack())
+					if cfg.Repanic {
+						panic(p)
+					}
+				}
+			}()
+		}
+
 		// pass the span through the request context
 		c.Request = c.Request.WithContext(ctx)
 
@@ -249,3 +249,63 @@ func TestPropagationWithCustomPropagators(t *testing.T) {
 
 	router.ServeHTTP(w, r)
 }
+
+func TestPanicRecovery(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	router := gin.New()
+	router.Use(Middleware("foobar", WithTracer(tracer), WithPanicRecovery(false)))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("oh no")
+	})
+	r := httptest.NewRequest("GET", "/panic", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, kv.IntValue(http.StatusInternalServerError), span.Attributes["http.status_code"])
+	assert.Equal(t, codes.Internal, span.Status)
+	assert.Equal(t, "oh no", span.StatusMessage)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+	assert.Equal(t, kv.StringValue("oh no"), span.Events[0].Attributes["exception.message"])
+
+	router = gin.New()
+	router.Use(Middleware("foobar", WithTracer(tracer), WithPanicRecovery(true)))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("oh no")
+	})
+	assert.PanicsWithValue(t, "oh no", func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", nil))
+	})
+	spans = tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Internal, spans[0].Status)
+}
+
+func TestPanicRecoveryAfterWrite(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	var status int
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		status = c.Writer.Status()
+	})
+	router.Use(Middleware("foobar", WithTracer(tracer), WithPanicRecovery(false)))
+	router.GET("/panic", func(c *gin.Context) {
+		c.String(http.StatusAccepted, "ok")
+		panic("oh no")
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+	assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+	assert.Equal(t, http.StatusAccepted, status, "no 500 is set after the response")
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "oh no", spans[0].StatusMessage)
+}
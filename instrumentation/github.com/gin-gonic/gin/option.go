@@ -24,6 +24,9 @@ import (
 type Config struct {
 	Tracer      oteltrace.Tracer
 	Propagators otelpropagation.Propagators
+
+	RecoverPanics bool
+	Repanic       bool
 }
 
 // Option specifies instrumentation configuration options.
@@ -47,3 +50,14 @@ func WithPropagators(propagators otelpropagation.Propagators) Option {
 		cfg.Propagators = propagators
 	}
 }
+
+// WithPanicRecovery makes the middleware recover from panics in later
+// handlers and record them on the span as an exception event with the stack
+// trace and an error status. With repanic the panic is re-raised for gin's
+// own Recovery middleware, otherwise the request is aborted with status 500.
+func WithPanicRecovery(repanic bool) Option {
+	return func(cfg *Config) {
+		cfg.RecoverPanics = true
+		cfg.Repanic = repanic
+	}
+}
@@ -49,7 +49,20 @@ const (
 	// made for the query in question.
 	cassQueryAttemptsKey = kv.Key("db.cassandra.attempts")
 
+	// cassPageKey is the key for the span attribute describing the index
+	// of the page fetched by a query attempt.
+	cassPageKey = kv.Key("db.cassandra.page")
+
+	// cassSpeculativeKey is the key for the span attribute describing
+	// whether a query attempt is a speculative execution.
+	cassSpeculativeKey = kv.Key("db.cassandra.speculative")
+
+	// cassRetryDecisionKey is the key for the span attribute describing
+	// the decision of the retry policy after a failed query attempt.
+	cassRetryDecisionKey = kv.Key("db.cassandra.retry.decision")
+
 	// Static span names
+	cassQueryName      = "Query"
 	cassBatchQueryName = "Batch Query"
 	cassConnectName    = "New Connection"
 
@@ -152,3 +165,21 @@ func cassRowsReturned(rows int) kv.KeyValue {
 func cassQueryAttempts(num int) kv.KeyValue {
 	return cassQueryAttemptsKey.Int(num)
 }
+
+// cassPage returns the KeyValue pair of the index of the page fetched
+// by a query attempt.
+func cassPage(page int) kv.KeyValue {
+	return cassPageKey.Int(page)
+}
+
+// cassSpeculative returns the KeyValue pair of whether a query attempt
+// is a speculative execution.
+func cassSpeculative(speculative bool) kv.KeyValue {
+	return cassSpeculativeKey.Bool(speculative)
+}
+
+// cassRetryDecision returns the KeyValue pair of the decision of the
+// retry policy after a failed query attempt.
+func cassRetryDecision(decision string) kv.KeyValue {
+	return cassRetryDecisionKey.String(decision)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/gocql/gocql"
 
 	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/trace"
 )
 
@@ -25,9 +26,14 @@ import (
 // created with NewSessionWithTracing.
 type TracedSessionConfig struct {
 	tracer            trace.Tracer
+	tracerProvider    trace.Provider
+	meterProvider     metric.Provider
 	instrumentQuery   bool
 	instrumentBatch   bool
 	instrumentConnect bool
+	normalizeStmt     bool
+	stmtMetricLabel   bool
+	page              Page
 	queryObserver     gocql.QueryObserver
 	batchObserver     gocql.BatchObserver
 	connectObserver   gocql.ConnectObserver
@@ -87,6 +93,25 @@ func WithTracer(tracer trace.Tracer) TracedSessionOption {
 	})
 }
 
+// WithTracerProvider will set the provider of the tracer used to create
+// spans for query, batch query, and connection instrumentation. It is
+// ignored if WithTracer is used. Defaults to global.TraceProvider().
+func WithTracerProvider(provider trace.Provider) TracedSessionOption {
+	return TracedSessionOptionFunc(func(c *TracedSessionConfig) {
+		c.tracerProvider = provider
+	})
+}
+
+// WithMeterProvider will set the provider of the meter used to record
+// metrics for the session, so that sessions can report to different
+// pipelines. Defaults to the provider given to InstrumentWithProvider,
+// which is global.MeterProvider() unless it is called.
+func WithMeterProvider(provider metric.Provider) TracedSessionOption {
+	return TracedSessionOptionFunc(func(c *TracedSessionConfig) {
+		c.meterProvider = provider
+	})
+}
+
 // WithQueryInstrumentation will enable and disable instrumentation of
 // queries. Defaults to enabled.
 func WithQueryInstrumentation(enabled bool) TracedSessionOption {
@@ -111,19 +136,55 @@ func WithConnectInstrumentation(enabled bool) TracedSessionOption {
 	})
 }
 
+// WithStatementNormalization will enable and disable the normalization of
+// query statements. When enabled, literal values are replaced by `?` in the
+// db.statement attribute and label, and spans are named after the operation
+// and table of the statement, such as `SELECT keyspace.table`, rather than
+// the statement itself. Defaults to enabled.
+func WithStatementNormalization(enabled bool) TracedSessionOption {
+	return TracedSessionOptionFunc(func(cfg *TracedSessionConfig) {
+		cfg.normalizeStmt = enabled
+	})
+}
+
+// WithStatementMetricLabel will enable and disable the db.statement label
+// of the query count metric. Defaults to enabled.
+func WithStatementMetricLabel(enabled bool) TracedSessionOption {
+	return TracedSessionOptionFunc(func(cfg *TracedSessionConfig) {
+		cfg.stmtMetricLabel = enabled
+	})
+}
+
+// WithPageState makes the query started by StartQuery resume from the
+// state of page, returned by PageState, and links its span to the span of
+// the query which returned it. It is ignored by NewSessionWithTracing.
+func WithPageState(page Page) TracedSessionOption {
+	return TracedSessionOptionFunc(func(cfg *TracedSessionConfig) {
+		cfg.page = page
+	})
+}
+
 // ------------------------------------------ Private Functions
 
 func configure(options ...TracedSessionOption) *TracedSessionConfig {
 	config := &TracedSessionConfig{
-		tracer:            global.Tracer(instrumentationName),
 		instrumentQuery:   true,
 		instrumentBatch:   true,
 		instrumentConnect: true,
+		normalizeStmt:     true,
+		stmtMetricLabel:   true,
 	}
 
 	for _, apply := range options {
 		apply.Apply(config)
 	}
 
+	if config.tracer == nil {
+		if config.tracerProvider == nil {
+			config.tracerProvider = global.TraceProvider()
+		}
+		config.tracer = config.tracerProvider.Tracer(instrumentationName)
+	}
+
 	return config
 }
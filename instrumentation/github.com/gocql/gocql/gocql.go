@@ -23,23 +23,55 @@ import (
 // NewSessionWithTracing creates a new session using the given cluster
 // configuration enabling tracing for queries, batch queries, and connection attempts.
 // You may use additional observers and disable specific tracing using the provided `TracedSessionOption`s.
+// The session is created from a copy of cluster, which is left unchanged
+// and can be used to create other sessions.
 func NewSessionWithTracing(ctx context.Context, cluster *gocql.ClusterConfig, options ...TracedSessionOption) (*gocql.Session, error) {
 	config := configure(options...)
+	copied := *cluster
+	cluster = &copied
+	// A nil *instruments uses the instruments of InstrumentWithProvider.
+	var inst *instruments
+	if config.meterProvider != nil {
+		inst = newInstruments(config.meterProvider)
+	}
+	var pool *hostPool
+	if config.instrumentConnect {
+		provider := config.meterProvider
+		if provider == nil {
+			provider = defaultProvider
+		}
+		pool = newHostPool(poolSetFor(provider))
+		cluster.Dialer = newPoolDialer(cluster, pool)
+		cluster.PoolConfig.HostSelectionPolicy = newPoolPolicy(cluster.PoolConfig.HostSelectionPolicy, pool)
+	}
+	if config.instrumentQuery && cluster.RetryPolicy != nil {
+		cluster.RetryPolicy = tracedRetryPolicy{cluster.RetryPolicy}
+	}
 	cluster.QueryObserver = &OTelQueryObserver{
-		enabled:  config.instrumentQuery,
-		observer: config.queryObserver,
-		tracer:   config.tracer,
+		enabled:         config.instrumentQuery,
+		observer:        config.queryObserver,
+		tracer:          config.tracer,
+		normalizeStmt:   config.normalizeStmt,
+		stmtMetricLabel: config.stmtMetricLabel,
+		instruments:     inst,
 	}
 	cluster.BatchObserver = &OTelBatchObserver{
-		enabled:  config.instrumentBatch,
-		observer: config.batchObserver,
-		tracer:   config.tracer,
+		enabled:     config.instrumentBatch,
+		observer:    config.batchObserver,
+		tracer:      config.tracer,
+		instruments: inst,
 	}
 	cluster.ConnectObserver = &OTelConnectObserver{
-		ctx:      ctx,
-		enabled:  config.instrumentConnect,
-		observer: config.connectObserver,
-		tracer:   config.tracer,
+		ctx:         ctx,
+		enabled:     config.instrumentConnect,
+		observer:    config.connectObserver,
+		tracer:      config.tracer,
+		instruments: inst,
+		pool:        pool,
+	}
+	session, err := cluster.CreateSession()
+	if err != nil && pool != nil {
+		pool.close()
 	}
-	return cluster.CreateSession()
+	return session, err
 }
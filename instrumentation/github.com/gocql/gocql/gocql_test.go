@@ -16,8 +16,12 @@ package gocql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"net"
 	"os"
 	"strings"
 	"testing"
@@ -34,6 +38,7 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/stretchr/testify/assert"
 
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
 	mocktracer "go.opentelemetry.io/contrib/internal/trace"
 	"go.opentelemetry.io/contrib/internal/util"
 
@@ -140,7 +145,7 @@ func TestQuery(t *testing.T) {
 	for _, span := range spans[0 : len(spans)-1] {
 
 		switch span.Name {
-		case insertStmt:
+		case "INSERT " + keyspace + "." + tableName:
 			assert.Equal(t, insertStmt, span.Attributes[standard.DBStatementKey].AsString())
 			assert.Equal(t, parentSpan.SpanContext().SpanID.String(), span.ParentSpanID.String())
 		default:
@@ -363,6 +368,37 @@ func TestConnection(t *testing.T) {
 				cassHostState("UP"),
 			},
 		},
+		{
+			Name:      "db.cassandra.connect.latency",
+			MeterName: instrumentationName,
+			Labels: []kv.KeyValue{
+				cassDBSystem(),
+				cassPeerIP("127.0.0.1"),
+				cassPeerPort(9042),
+				cassVersion("3"),
+				cassHostID("test-id"),
+				cassHostState("UP"),
+			},
+		},
+		{
+			Name:      "db.cassandra.connections.open",
+			MeterName: instrumentationName,
+			Labels: []kv.KeyValue{
+				cassDBSystem(),
+				cassPeerIP("127.0.0.1"),
+				cassPeerPort(9042),
+			},
+		},
+		{
+			Name:      "db.cassandra.host.up",
+			MeterName: instrumentationName,
+			Labels: []kv.KeyValue{
+				cassDBSystem(),
+				cassPeerIP("127.0.0.1"),
+				cassPeerPort(9042),
+			},
+			Number: 1,
+		},
 	}
 
 	for _, record := range exporter.records {
@@ -370,12 +406,266 @@ func TestConnection(t *testing.T) {
 		switch name {
 		case "db.cassandra.connections":
 			recordEqual(t, expected[0], record)
+		case "db.cassandra.connect.latency":
+			recordEqual(t, expected[1], record)
+		case "db.cassandra.connections.open":
+			recordEqual(t, expected[2], record)
+		case "db.cassandra.host.up":
+			recordEqual(t, expected[3], record)
+			numberEqual(t, expected[3].Number, record.Aggregation())
 		default:
 			t.Fatalf("wrong metric %s", name)
 		}
 	}
 }
 
+type mockTraceProvider struct {
+	tracer *mocktracer.Tracer
+}
+
+func (p mockTraceProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return p.tracer
+}
+
+func TestSessionProviders(t *testing.T) {
+	observe := func(options ...TracedSessionOption) {
+		config := configure(options...)
+		observer := &OTelConnectObserver{
+			ctx:         context.Background(),
+			enabled:     true,
+			tracer:      config.tracer,
+			instruments: newInstruments(config.meterProvider),
+		}
+		observer.ObserveConnect(gocql.ObservedConnect{
+			Host: (&gocql.HostInfo{}).SetConnectAddress(net.IPv4(127, 0, 0, 1)),
+		})
+	}
+
+	tracers := []*mocktracer.Tracer{mocktracer.NewTracer("a"), mocktracer.NewTracer("b")}
+	meters := make([]*mockmeter.MeterImpl, 2)
+	for i := range meters {
+		var provider metric.Provider
+		meters[i], provider = mockmeter.NewProvider()
+		observe(WithTracerProvider(mockTraceProvider{tracers[i]}), WithMeterProvider(provider))
+	}
+
+	for i := range meters {
+		assert.Len(t, tracers[i].EndedSpans(), 1)
+		names := map[string]bool{}
+		for _, batch := range meters[i].MeasurementBatches {
+			for _, m := range batch.Measurements {
+				names[m.Instrument.Descriptor().Name()] = true
+			}
+		}
+		assert.Equal(t, map[string]bool{
+			"db.cassandra.connections":     true,
+			"db.cassandra.connect.latency": true,
+		}, names)
+	}
+}
+
+type stubRetryPolicy struct{}
+
+func (stubRetryPolicy) Attempt(gocql.RetryableQuery) bool {
+	return true
+}
+
+func (stubRetryPolicy) GetRetryType(error) gocql.RetryType {
+	return gocql.RetryNextHost
+}
+
+func TestQuerySpan(t *testing.T) {
+	tracer := mocktracer.NewTracer("gocql-test")
+	policy := tracedRetryPolicy{stubRetryPolicy{}}
+
+	q := StartQuery(&gocql.Query{}, WithTracer(tracer))
+	qs := querySpanFromContext(q.Context())
+	require.NotNil(t, qs)
+
+	start := time.Now()
+	attempt := func(offset int, err error) {
+		_, span := tracer.Start(q.Context(), "attempt")
+		qs.observe(span, gocql.ObservedQuery{
+			Keyspace:  keyspace,
+			Statement: "select * from t where id = ?",
+			Start:     start.Add(time.Duration(offset) * time.Second),
+			End:       start.Add(time.Duration(offset+2) * time.Second),
+			Err:       err,
+		}, "SELECT gotest.t", "select * from t where id = ?")
+	}
+
+	// A failed attempt, retried on the next host.
+	attempt(0, errors.New("timeout"))
+	assert.True(t, policy.Attempt(q))
+	// A successful attempt and a speculative execution.
+	attempt(3, nil)
+	attempt(4, nil)
+	// The next page.
+	attempt(10, nil)
+	EndQuery(q, nil)
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 5)
+	logical := spans[4]
+	assert.Equal(t, "SELECT gotest.t", logical.Name)
+	assert.Equal(t, int64(4), logical.Attributes[cassQueryAttemptsKey].AsInt64())
+
+	for _, span := range spans[:4] {
+		assert.Equal(t, logical.SpanContext().SpanID, span.ParentSpanID)
+	}
+	assert.Equal(t, "retry_next_host", spans[0].Attributes[cassRetryDecisionKey].AsString())
+	assert.NotContains(t, spans[1].Attributes, cassSpeculativeKey)
+	assert.True(t, spans[2].Attributes[cassSpeculativeKey].AsBool())
+	assert.Equal(t, int64(0), spans[2].Attributes[cassPageKey].AsInt64())
+	assert.Equal(t, int64(1), spans[3].Attributes[cassPageKey].AsInt64())
+}
+
+func TestQuerySpanPageState(t *testing.T) {
+	tracer := mocktracer.NewTracer("gocql-test")
+
+	first := StartQuery(&gocql.Query{}, WithTracer(tracer))
+	page := PageState(first, &gocql.Iter{})
+	EndQuery(first, nil)
+	assert.Equal(t, trace.SpanFromContext(first.Context()).SpanContext(), page.SpanContext)
+	assert.Empty(t, page.State, "the iterator has no page state")
+
+	// Without page state the query starts from the first page, unlinked.
+	EndQuery(StartQuery(&gocql.Query{}, WithTracer(tracer), WithPageState(page)), nil)
+
+	page.State = []byte("state")
+	next := StartQuery(&gocql.Query{}, WithTracer(tracer), WithPageState(page))
+	EndQuery(next, errors.New("test"))
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 3)
+	assert.Empty(t, spans[1].Links)
+	assert.Contains(t, spans[2].Links, spans[0].SpanContext())
+	assert.Equal(t, "test", spans[2].Attributes[cassErrMsgKey].AsString())
+}
+
+func TestConnectObserverError(t *testing.T) {
+	meterimpl, provider := mockmeter.NewProvider()
+	observer := &OTelConnectObserver{
+		ctx:         context.Background(),
+		enabled:     true,
+		tracer:      mocktracer.NewTracer("gocql-test"),
+		instruments: newInstruments(provider),
+	}
+	observer.ObserveConnect(gocql.ObservedConnect{
+		Host: (&gocql.HostInfo{}).SetConnectAddress(net.IPv4(127, 0, 0, 1)),
+		Err:  errors.New("connection refused"),
+	})
+
+	names := map[string]bool{}
+	for _, batch := range meterimpl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			names[m.Instrument.Descriptor().Name()] = true
+		}
+		assert.Contains(t, batch.Labels, cassErrMsg("connection refused"))
+	}
+	assert.True(t, names["db.cassandra.connect.latency"])
+}
+
+func TestHostPool(t *testing.T) {
+	meterimpl, provider := mockmeter.NewProvider()
+	set := poolSetFor(provider)
+	assert.Same(t, set, poolSetFor(provider), "the instruments of a provider must be registered once")
+	pool := newHostPool(set)
+
+	cluster := gocql.NewCluster()
+	cluster.Dialer = pipeDialer{}
+	dialer := newPoolDialer(cluster, pool)
+	addr := "127.0.0.1:0"
+	conns := make([]net.Conn, 2)
+	var err error
+	for i := range conns {
+		conns[i], err = dialer.DialContext(context.Background(), "tcp", addr)
+		require.NoError(t, err)
+	}
+	require.NoError(t, conns[0].Close())
+	// Closing twice must not count twice.
+	_ = conns[0].Close()
+	require.NoError(t, conns[1].Close())
+	conns[1], err = dialer.DialContext(context.Background(), "tcp", addr)
+	require.NoError(t, err)
+
+	host := (&gocql.HostInfo{}).SetConnectAddress(net.IPv4(127, 0, 0, 1))
+	require.Equal(t, addr, host.HostnameAndPort())
+	pool.observeHost(host)
+	policy := newPoolPolicy(nil, pool)
+	session := &gocql.Session{}
+	policy.Init(session)
+
+	observe := func() map[string]int64 {
+		meterimpl.MeasurementBatches = nil
+		meterimpl.RunAsyncInstruments()
+		got := map[string]int64{}
+		for _, batch := range meterimpl.MeasurementBatches {
+			for _, m := range batch.Measurements {
+				got[m.Instrument.Descriptor().Name()] = m.Number.AsInt64()
+			}
+		}
+		return got
+	}
+	got := observe()
+	assert.Equal(t, int64(1), got["db.cassandra.connections.open"])
+	assert.Equal(t, int64(1), got["db.cassandra.host.up"])
+
+	policy.HostDown(host)
+	require.NoError(t, conns[1].Close())
+	got = observe()
+	assert.NotContains(t, got, "db.cassandra.connections.open")
+	require.Contains(t, got, "db.cassandra.host.up", "hosts without connections must be reported")
+	assert.Equal(t, int64(0), got["db.cassandra.host.up"], "a down host must report 0")
+
+	policy.HostUp(host)
+	assert.Equal(t, int64(1), observe()["db.cassandra.host.up"])
+	policy.RemoveHost(host)
+	assert.Empty(t, observe(), "removed hosts must be forgotten")
+
+	pool.observeHost(host)
+	conns[0], err = dialer.DialContext(context.Background(), "tcp", addr)
+	require.NoError(t, err)
+	session.Close()
+	require.NoError(t, conns[0].Close())
+	assert.Eventually(t, func() bool {
+		return len(observe()) == 0
+	}, time.Second, 5*time.Millisecond, "the pools of closed sessions must be forgotten")
+}
+
+// pipeDialer dials in-memory connections to any address.
+type pipeDialer struct{}
+
+func (pipeDialer) DialContext(context.Context, string, string) (net.Conn, error) {
+	client, server := net.Pipe()
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, server)
+		server.Close()
+	}()
+	return client, nil
+}
+
+func TestNewSessionWithTracingKeepsCluster(t *testing.T) {
+	cluster := gocql.NewCluster("127.0.0.1:1")
+	cluster.ConnectTimeout = time.Millisecond
+	cluster.DisableInitialHostLookup = true
+	retry := &gocql.SimpleRetryPolicy{NumRetries: 1}
+	cluster.RetryPolicy = retry
+
+	for i := 0; i < 2; i++ {
+		session, err := NewSessionWithTracing(context.Background(), cluster, WithTracer(mocktracer.NewTracer("gocql-test")))
+		if err == nil {
+			session.Close()
+		}
+	}
+	assert.Nil(t, cluster.Dialer)
+	assert.Nil(t, cluster.PoolConfig.HostSelectionPolicy)
+	assert.Equal(t, retry, cluster.RetryPolicy)
+	assert.Nil(t, cluster.QueryObserver)
+	assert.Nil(t, cluster.BatchObserver)
+	assert.Nil(t, cluster.ConnectObserver)
+}
+
 func TestHostOrIP(t *testing.T) {
 	hostAndPort := "127.0.0.1:9042"
 	attribute := hostOrIP(hostAndPort)
@@ -392,6 +682,45 @@ func TestHostOrIP(t *testing.T) {
 	require.Empty(t, attribute.Value.AsString())
 }
 
+func TestNormalizeStatement(t *testing.T) {
+	testCases := []struct {
+		stmt     string
+		expected string
+	}{
+		{"select * from t where id = ?", "select * from t where id = ?"},
+		{"SELECT title FROM t WHERE id = 42 AND name = 'it''s'", "SELECT title FROM t WHERE id = ? AND name = ?"},
+		{"insert into t (id, title, data) values (123e4567-e89b-12d3-a456-426614174000, $$x$$, 0xCAFE)", "insert into t (id, title, data) values (?, ?, ?)"},
+		{"update t2 set score = -1.5e-3 where id in (1, 2)", "update t2 set score = ? where id in (?, ?)"},
+		{"select  \"Col1\"\n  from t1", "select \"Col1\" from t1"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, normalizeStatement(tc.stmt), tc.stmt)
+	}
+}
+
+func TestStatementSpanName(t *testing.T) {
+	testCases := []struct {
+		stmt     string
+		expected string
+	}{
+		{"select * from t where id = ?", "SELECT gotest.t"},
+		{"SELECT count(*) FROM other.t", "SELECT other.t"},
+		{"insert into t(id) values (?)", "INSERT gotest.t"},
+		{"update t set a = ? where id = ?", "UPDATE gotest.t"},
+		{"delete from t where id = ?", "DELETE gotest.t"},
+		{"truncate table t", "TRUNCATE gotest.t"},
+		{"create table if not exists t(id UUID, PRIMARY KEY(id))", "CREATE TABLE gotest.t"},
+		{"create keyspace if not exists ks with replication = ?", "CREATE KEYSPACE ks"},
+		{"begin batch insert into t(id) values (?) apply batch", "BATCH"},
+		{"use ks", "USE ks"},
+		{"grant select on t to r", "GRANT"},
+		{"", "Query"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, statementSpanName(tc.stmt, keyspace), tc.stmt)
+	}
+}
+
 func assertConnectionLevelAttributes(t *testing.T, span *mocktracer.Span) {
 	assert.Equal(t, span.Attributes[standard.DBSystemKey].AsString(),
 		standard.DBSystemCassandra.Value.AsString(),
@@ -23,65 +23,105 @@ import (
 	"go.opentelemetry.io/otel/api/unit"
 )
 
-var (
-	// iQueryCount is the number of queries executed.
-	iQueryCount metric.Int64Counter
+// instruments holds the instruments used to record the metrics of traced
+// sessions.
+type instruments struct {
+	// queryCount is the number of queries executed.
+	queryCount metric.Int64Counter
 
-	// iQueryRows is the number of rows returned by a query.
-	iQueryRows metric.Int64ValueRecorder
+	// queryRows is the number of rows returned by a query.
+	queryRows metric.Int64ValueRecorder
 
-	// iBatchCount is the number of batch queries executed.
-	iBatchCount metric.Int64Counter
+	// batchCount is the number of batch queries executed.
+	batchCount metric.Int64Counter
 
-	// iConnectionCount is the number of connections made
+	// connectionCount is the number of connections made
 	// with the traced session.
-	iConnectionCount metric.Int64Counter
+	connectionCount metric.Int64Counter
+
+	// latency is the sum of attempt latencies.
+	latency metric.Int64ValueRecorder
+
+	// connectLatency is the latency of connection attempts.
+	connectLatency metric.Int64ValueRecorder
+}
 
-	// iLatency is the sum of attempt latencies.
-	iLatency metric.Int64ValueRecorder
+// defaultInstruments are used by the sessions which were not given a
+// metric.Provider with WithMeterProvider, and defaultProvider is the
+// provider they were created with.
+var (
+	defaultInstruments *instruments
+	defaultProvider    metric.Provider
 )
 
 // InstrumentWithProvider will recreate instruments using a meter
-// from the given provider p.
+// from the given provider p. The instruments are used by all the
+// sessions which were not given a provider with WithMeterProvider.
 func InstrumentWithProvider(p metric.Provider) {
+	defaultInstruments = newInstruments(p)
+	defaultProvider = p
+}
+
+// newInstruments creates the instruments using a meter from the
+// given provider p.
+func newInstruments(p metric.Provider) *instruments {
 	meter := p.Meter(instrumentationName)
+	inst := &instruments{}
 	var err error
 
-	if iQueryCount, err = meter.NewInt64Counter(
+	if inst.queryCount, err = meter.NewInt64Counter(
 		"db.cassandra.queries",
 		metric.WithDescription("Number queries executed"),
 	); err != nil {
-		log.Printf("failed to create iQueryCount instrument, %v", err)
+		log.Printf("failed to create queryCount instrument, %v", err)
 	}
 
-	if iQueryRows, err = meter.NewInt64ValueRecorder(
+	if inst.queryRows, err = meter.NewInt64ValueRecorder(
 		"db.cassandra.rows",
 		metric.WithDescription("Number of rows returned from query"),
 	); err != nil {
-		log.Printf("failed to create iQueryRows instrument, %v", err)
+		log.Printf("failed to create queryRows instrument, %v", err)
 	}
 
-	if iBatchCount, err = meter.NewInt64Counter(
+	if inst.batchCount, err = meter.NewInt64Counter(
 		"db.cassandra.batch.queries",
 		metric.WithDescription("Number of batch queries executed"),
 	); err != nil {
-		log.Printf("failed to create iBatchCount instrument, %v", err)
+		log.Printf("failed to create batchCount instrument, %v", err)
 	}
 
-	if iConnectionCount, err = meter.NewInt64Counter(
+	if inst.connectionCount, err = meter.NewInt64Counter(
 		"db.cassandra.connections",
 		metric.WithDescription("Number of connections created"),
 	); err != nil {
-		log.Printf("failed to create iConnectionCount instrument, %v", err)
+		log.Printf("failed to create connectionCount instrument, %v", err)
 	}
 
-	if iLatency, err = meter.NewInt64ValueRecorder(
+	if inst.latency, err = meter.NewInt64ValueRecorder(
 		"db.cassandra.latency",
 		metric.WithDescription("Sum of latency to host in milliseconds"),
 		metric.WithUnit(unit.Milliseconds),
 	); err != nil {
-		log.Printf("failed to create iLatency instrument, %v", err)
+		log.Printf("failed to create latency instrument, %v", err)
+	}
+
+	if inst.connectLatency, err = meter.NewInt64ValueRecorder(
+		"db.cassandra.connect.latency",
+		metric.WithDescription("Latency of connection attempts in milliseconds"),
+		metric.WithUnit(unit.Milliseconds),
+	); err != nil {
+		log.Printf("failed to create connectLatency instrument, %v", err)
+	}
+
+	return inst
+}
+
+// orDefault returns inst, or the default instruments if inst is nil.
+func (inst *instruments) orDefault() *instruments {
+	if inst == nil {
+		return defaultInstruments
 	}
+	return inst
 }
 
 func init() {
@@ -29,26 +29,32 @@ import (
 // OTelQueryObserver implements the gocql.QueryObserver interface
 // to provide instrumentation to gocql queries.
 type OTelQueryObserver struct {
-	enabled  bool
-	observer gocql.QueryObserver
-	tracer   trace.Tracer
+	enabled         bool
+	observer        gocql.QueryObserver
+	tracer          trace.Tracer
+	normalizeStmt   bool
+	stmtMetricLabel bool
+	instruments     *instruments
 }
 
 // OTelBatchObserver implements the gocql.BatchObserver interface
 // to provide instrumentation to gocql batch queries.
 type OTelBatchObserver struct {
-	enabled  bool
-	observer gocql.BatchObserver
-	tracer   trace.Tracer
+	enabled     bool
+	observer    gocql.BatchObserver
+	tracer      trace.Tracer
+	instruments *instruments
 }
 
 // OTelConnectObserver implements the gocql.ConnectObserver interface
 // to provide instrumentation to connection attempts made by the session.
 type OTelConnectObserver struct {
-	ctx      context.Context
-	enabled  bool
-	observer gocql.ConnectObserver
-	tracer   trace.Tracer
+	ctx         context.Context
+	enabled     bool
+	observer    gocql.ConnectObserver
+	tracer      trace.Tracer
+	instruments *instruments
+	pool        *hostPool
 }
 
 // ------------------------------------------ Observer Functions
@@ -56,54 +62,53 @@ type OTelConnectObserver struct {
 // ObserveQuery is called once per query, and provides instrumentation for it.
 func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocql.ObservedQuery) {
 	if o.enabled {
+		inst := o.instruments.orDefault()
 		host := observedQuery.Host
 		keyspace := observedQuery.Keyspace
 
+		stmt, spanName := observedQuery.Statement, observedQuery.Statement
+		if o.normalizeStmt {
+			stmt = normalizeStatement(stmt)
+			spanName = statementSpanName(stmt, keyspace)
+		}
+
 		attributes := includeKeyValues(host,
 			cassKeyspace(keyspace),
-			cassStatement(observedQuery.Statement),
+			cassStatement(stmt),
 			cassRowsReturned(observedQuery.Rows),
 			cassQueryAttempts(observedQuery.Metrics.Attempts),
 		)
 
 		ctx, span := o.tracer.Start(
 			ctx,
-			observedQuery.Statement,
+			spanName,
 			trace.WithStartTime(observedQuery.Start),
 			trace.WithAttributes(attributes...),
 			trace.WithSpanKind(trace.SpanKindClient),
 		)
 
+		labels := []kv.KeyValue{cassKeyspace(keyspace)}
+		if o.stmtMetricLabel {
+			labels = append(labels, cassStatement(stmt))
+		}
 		if observedQuery.Err != nil {
 			span.SetAttributes(cassErrMsg(observedQuery.Err.Error()))
-			iQueryCount.Add(
-				ctx,
-				1,
-				includeKeyValues(host,
-					cassKeyspace(keyspace),
-					cassStatement(observedQuery.Statement),
-					cassErrMsg(observedQuery.Err.Error()),
-				)...,
-			)
-		} else {
-			iQueryCount.Add(
-				ctx,
-				1,
-				includeKeyValues(host,
-					cassKeyspace(keyspace),
-					cassStatement(observedQuery.Statement),
-				)...,
-			)
+			labels = append(labels, cassErrMsg(observedQuery.Err.Error()))
 		}
+		inst.queryCount.Add(ctx, 1, includeKeyValues(host, labels...)...)
 
-		span.End(trace.WithEndTime(observedQuery.End))
+		if qs := querySpanFromContext(ctx); qs != nil {
+			qs.observe(span, observedQuery, spanName, stmt)
+		} else {
+			span.End(trace.WithEndTime(observedQuery.End))
+		}
 
-		iQueryRows.Record(
+		inst.queryRows.Record(
 			ctx,
 			int64(observedQuery.Rows),
 			includeKeyValues(host, cassKeyspace(keyspace))...,
 		)
-		iLatency.Record(
+		inst.latency.Record(
 			ctx,
 			nanoToMilliseconds(observedQuery.Metrics.TotalLatency),
 			includeKeyValues(host, cassKeyspace(keyspace))...,
@@ -118,6 +123,7 @@ func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocq
 // ObserveBatch is called once per batch query, and provides instrumentation for it.
 func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocql.ObservedBatch) {
 	if o.enabled {
+		inst := o.instruments.orDefault()
 		host := observedBatch.Host
 		keyspace := observedBatch.Keyspace
 
@@ -137,7 +143,7 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 
 		if observedBatch.Err != nil {
 			span.SetAttributes(cassErrMsg(observedBatch.Err.Error()))
-			iBatchCount.Add(
+			inst.batchCount.Add(
 				ctx,
 				1,
 				includeKeyValues(host,
@@ -146,7 +152,7 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 				)...,
 			)
 		} else {
-			iBatchCount.Add(
+			inst.batchCount.Add(
 				ctx,
 				1,
 				includeKeyValues(host, cassKeyspace(keyspace))...,
@@ -155,7 +161,7 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 
 		span.End(trace.WithEndTime(observedBatch.End))
 
-		iLatency.Record(
+		inst.latency.Record(
 			ctx,
 			nanoToMilliseconds(observedBatch.Metrics.TotalLatency),
 			includeKeyValues(host, cassKeyspace(keyspace))...,
@@ -170,6 +176,7 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 // ObserveConnect is called once per connection attempt, and provides instrumentation for it.
 func (o *OTelConnectObserver) ObserveConnect(observedConnect gocql.ObservedConnect) {
 	if o.enabled {
+		inst := o.instruments.orDefault()
 		host := observedConnect.Host
 
 		attributes := includeKeyValues(host, cassConnectOperation())
@@ -182,22 +189,27 @@ func (o *OTelConnectObserver) ObserveConnect(observedConnect gocql.ObservedConne
 			trace.WithSpanKind(trace.SpanKindClient),
 		)
 
+		labels := includeKeyValues(host)
 		if observedConnect.Err != nil {
 			span.SetAttributes(cassErrMsg(observedConnect.Err.Error()))
-			iConnectionCount.Add(
-				o.ctx,
-				1,
-				includeKeyValues(host, cassErrMsg(observedConnect.Err.Error()))...,
-			)
-		} else {
-			iConnectionCount.Add(
-				o.ctx,
-				1,
-				includeKeyValues(host)...,
-			)
+			labels = includeKeyValues(host, cassErrMsg(observedConnect.Err.Error()))
 		}
+		inst.connectionCount.Add(
+			o.ctx,
+			1,
+			labels...,
+		)
 
 		span.End(trace.WithEndTime(observedConnect.End))
+
+		inst.connectLatency.Record(
+			o.ctx,
+			nanoToMilliseconds(observedConnect.End.Sub(observedConnect.Start).Nanoseconds()),
+			labels...,
+		)
+		if o.pool != nil {
+			o.pool.observeHost(host)
+		}
 	}
 
 	if o.observer != nil {
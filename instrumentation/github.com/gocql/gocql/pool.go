@@ -0,0 +1,324 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gocql
+
+import (
+	"context"
+	"log"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/gocql/gocql"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
+)
+
+// hostPool tracks the hosts a traced session connects to, whether they are
+// up and the number of connections open to each of them. A host is reported
+// from its first connection attempt until it is removed from the cluster,
+// and the pool is reported until its session is closed.
+type hostPool struct {
+	mu sync.Mutex
+	// up holds whether each host is up, as last observed on a connection
+	// attempt or notified to the host selection policy of the session.
+	up    map[string]bool
+	conns map[string]int64
+	// open is the number of connections open to all the hosts.
+	open int64
+	// session is the session of the pool, set when the session initializes
+	// its host selection policy.
+	session *gocql.Session
+	set     *poolSet
+}
+
+// newHostPool returns a pool reported with the pools of set.
+func newHostPool(set *poolSet) *hostPool {
+	p := &hostPool{
+		up:    make(map[string]bool),
+		conns: make(map[string]int64),
+		set:   set,
+	}
+	set.add(p)
+	return p
+}
+
+// observeHost records host, whose state is then reported until it is
+// removed.
+func (p *hostPool) observeHost(host *gocql.HostInfo) {
+	p.setHostUp(host, host.IsUp())
+}
+
+// setHostUp records whether host is up.
+func (p *hostPool) setHostUp(host *gocql.HostInfo, up bool) {
+	addr := host.HostnameAndPort()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.up[addr] = up
+}
+
+// removeHost forgets host.
+func (p *hostPool) removeHost(host *gocql.HostInfo) {
+	addr := host.HostnameAndPort()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.up, addr)
+}
+
+// setSession records the session of the pool.
+func (p *hostPool) setSession(session *gocql.Session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.session = session
+}
+
+// add adds n to the number of connections open to addr. Once no connection
+// is open, the pool is closed if its session is.
+func (p *hostPool) add(addr string, n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.conns[addr] += n
+	if p.conns[addr] <= 0 {
+		delete(p.conns, addr)
+	}
+	p.open += n
+	if p.open <= 0 && p.session != nil {
+		// Session.Close closes the connections while holding the lock
+		// taken by Session.Closed, which thus returns once Close did.
+		go func(session *gocql.Session) {
+			if session.Closed() {
+				p.close()
+			}
+		}(p.session)
+	}
+}
+
+// close stops reporting the pool.
+func (p *hostPool) close() {
+	p.set.remove(p)
+}
+
+// observe reports the number of open connections and the state of each
+// host with the given observers.
+func (p *hostPool) observe(result metric.BatchObserverResult, openConns, hostUp metric.Int64ValueObserver) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for addr, n := range p.conns {
+		result.Observe(poolLabels(addr), openConns.Observation(n))
+	}
+	for addr, up := range p.up {
+		var v int64
+		if up {
+			v = 1
+		}
+		result.Observe(poolLabels(addr), hostUp.Observation(v))
+	}
+}
+
+// poolSet holds the pools of the open sessions reporting to the same
+// metric.Provider, which are observed by a single batch observer.
+type poolSet struct {
+	mu    sync.Mutex
+	pools map[*hostPool]struct{}
+}
+
+// poolSets holds the pool set of each provider, so that the instruments of
+// a provider are registered once however many sessions are created.
+var poolSets = struct {
+	sync.Mutex
+	m map[metric.Provider]*poolSet
+}{m: make(map[metric.Provider]*poolSet)}
+
+// poolSetFor returns the pool set of provider, creating it and registering
+// its instruments on first use. Providers of a type that cannot be used as
+// a map key get a new set every time.
+func poolSetFor(provider metric.Provider) *poolSet {
+	keyed := reflect.TypeOf(provider).Comparable()
+	if keyed {
+		poolSets.Lock()
+		defer poolSets.Unlock()
+		if set, ok := poolSets.m[provider]; ok {
+			return set
+		}
+	}
+	set := &poolSet{pools: make(map[*hostPool]struct{})}
+	registerPoolInstruments(provider, set)
+	if keyed {
+		poolSets.m[provider] = set
+	}
+	return set
+}
+
+func (s *poolSet) add(p *hostPool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pools[p] = struct{}{}
+}
+
+func (s *poolSet) remove(p *hostPool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pools, p)
+}
+
+// observe reports the state of each pool of s with the given observers.
+func (s *poolSet) observe(result metric.BatchObserverResult, openConns, hostUp metric.Int64ValueObserver) {
+	// The pools are locked after s is unlocked, since they update s while
+	// they are locked.
+	s.mu.Lock()
+	pools := make([]*hostPool, 0, len(s.pools))
+	for p := range s.pools {
+		pools = append(pools, p)
+	}
+	s.mu.Unlock()
+
+	for _, p := range pools {
+		p.observe(result, openConns, hostUp)
+	}
+}
+
+// poolLabels returns the labels of the host at addr.
+func poolLabels(addr string) []kv.KeyValue {
+	labels := []kv.KeyValue{cassDBSystem(), hostOrIP(addr)}
+	if _, port, err := net.SplitHostPort(addr); err == nil {
+		if p, err := strconv.Atoi(port); err == nil {
+			labels = append(labels, cassPeerPort(p))
+		}
+	}
+	return labels
+}
+
+// registerPoolInstruments creates the asynchronous instruments reporting
+// the state of the pools of set, using a meter from the given provider.
+func registerPoolInstruments(provider metric.Provider, set *poolSet) {
+	meter := provider.Meter(instrumentationName)
+	var openConns, hostUp metric.Int64ValueObserver
+	batch := meter.NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+		set.observe(result, openConns, hostUp)
+	})
+	var err error
+
+	if openConns, err = batch.NewInt64ValueObserver(
+		"db.cassandra.connections.open",
+		metric.WithDescription("Number of connections open to a host"),
+	); err != nil {
+		log.Printf("failed to create openConns instrument, %v", err)
+	}
+
+	if hostUp, err = batch.NewInt64ValueObserver(
+		"db.cassandra.host.up",
+		metric.WithDescription("State of a host, 1 if it is up and 0 if it is down"),
+	); err != nil {
+		log.Printf("failed to create hostUp instrument, %v", err)
+	}
+}
+
+// poolDialer is a gocql.Dialer which counts the open connections of pool.
+type poolDialer struct {
+	gocql.Dialer
+	pool *hostPool
+}
+
+// newPoolDialer returns a poolDialer using the dialer of cluster, or the
+// dialer gocql uses by default if it has none.
+func newPoolDialer(cluster *gocql.ClusterConfig, pool *hostPool) *poolDialer {
+	dialer := cluster.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{
+			Timeout:   cluster.ConnectTimeout,
+			KeepAlive: cluster.SocketKeepalive,
+		}
+	}
+	return &poolDialer{Dialer: dialer, pool: pool}
+}
+
+// DialContext calls gocql.Dialer.DialContext and counts the connection as
+// open until it is closed.
+func (d *poolDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := d.Dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	d.pool.add(addr, 1)
+	return &poolConn{Conn: conn, closed: func() { d.pool.add(addr, -1) }}, nil
+}
+
+// poolConn calls closed once it is closed.
+type poolConn struct {
+	net.Conn
+	once   sync.Once
+	closed func()
+}
+
+// Close closes the connection.
+func (c *poolConn) Close() error {
+	c.once.Do(c.closed)
+	return c.Conn.Close()
+}
+
+// poolPolicy is a gocql.HostSelectionPolicy which keeps pool up to date with
+// the session and the state of its hosts.
+type poolPolicy struct {
+	gocql.HostSelectionPolicy
+	pool *hostPool
+}
+
+// newPoolPolicy returns a poolPolicy wrapping policy, or the policy gocql
+// uses by default if it is nil.
+func newPoolPolicy(policy gocql.HostSelectionPolicy, pool *hostPool) *poolPolicy {
+	if policy == nil {
+		policy = gocql.RoundRobinHostPolicy()
+	}
+	return &poolPolicy{HostSelectionPolicy: policy, pool: pool}
+}
+
+// Init records the session of the pool and initializes the wrapped policy.
+func (p *poolPolicy) Init(session *gocql.Session) {
+	p.pool.setSession(session)
+	p.HostSelectionPolicy.Init(session)
+}
+
+// AddHosts adds hosts in bulk if the wrapped policy supports it, as gocql
+// only does for the policies that implement it.
+func (p *poolPolicy) AddHosts(hosts []*gocql.HostInfo) {
+	if bulk, ok := p.HostSelectionPolicy.(interface{ AddHosts([]*gocql.HostInfo) }); ok {
+		bulk.AddHosts(hosts)
+		return
+	}
+	for _, host := range hosts {
+		p.HostSelectionPolicy.AddHost(host)
+	}
+}
+
+// RemoveHost forgets host and removes it from the wrapped policy.
+func (p *poolPolicy) RemoveHost(host *gocql.HostInfo) {
+	p.pool.removeHost(host)
+	p.HostSelectionPolicy.RemoveHost(host)
+}
+
+// HostUp records host as up and notifies the wrapped policy.
+func (p *poolPolicy) HostUp(host *gocql.HostInfo) {
+	p.pool.setHostUp(host, true)
+	p.HostSelectionPolicy.HostUp(host)
+}
+
+// HostDown records host as down and notifies the wrapped policy.
+func (p *poolPolicy) HostDown(host *gocql.HostInfo) {
+	p.pool.setHostUp(host, false)
+	p.HostSelectionPolicy.HostDown(host)
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gocql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+type querySpanKey struct{}
+
+// querySpan is the span of a logical query, started by StartQuery, whose
+// children are the spans of the attempts made to execute it.
+type querySpan struct {
+	span trace.Span
+
+	mu       sync.Mutex
+	named    bool
+	attempts int
+	page     int
+	lastOK   bool
+	observed []attemptInterval
+	// pending is the span of the last failed attempt, waiting for the
+	// decision of the retry policy before being ended.
+	pending    trace.Span
+	pendingErr error
+	pendingEnd time.Time
+}
+
+type attemptInterval struct {
+	start, end time.Time
+}
+
+func querySpanFromContext(ctx context.Context) *querySpan {
+	qs, _ := ctx.Value(querySpanKey{}).(*querySpan)
+	return qs
+}
+
+// StartQuery starts a span for the logical query q and returns a copy of q
+// bound to the context of the span, so that the spans of the attempts made to execute q, be they
+// retries, speculative executions or page fetches, are its children. The
+// span must be ended with EndQuery.
+//
+// The tracer is configured with WithTracer or WithTracerProvider, the span
+// name with WithStatementNormalization. If WithPageState is used, q resumes
+// from the given page state and the span is linked to the query which
+// returned it.
+func StartQuery(q *gocql.Query, options ...TracedSessionOption) *gocql.Query {
+	config := configure(options...)
+
+	opts := []trace.StartOption{
+		trace.WithAttributes(cassDBSystem()),
+		trace.WithSpanKind(trace.SpanKindClient),
+	}
+	if config.page.State != nil {
+		q = q.PageState(config.page.State)
+		if config.page.SpanContext.IsValid() {
+			opts = append(opts, trace.LinkedTo(config.page.SpanContext))
+		}
+	}
+
+	ctx, span := config.tracer.Start(q.Context(), cassQueryName, opts...)
+	qs := &querySpan{span: span}
+	return q.WithContext(context.WithValue(ctx, querySpanKey{}, qs))
+}
+
+// EndQuery ends the span of q started with StartQuery, recording err if it
+// is not nil.
+func EndQuery(q *gocql.Query, err error) {
+	qs := querySpanFromContext(q.Context())
+	if qs == nil {
+		return
+	}
+
+	qs.mu.Lock()
+	qs.endPending("")
+	attempts := qs.attempts
+	qs.mu.Unlock()
+
+	qs.span.SetAttributes(cassQueryAttempts(attempts))
+	if err != nil {
+		qs.span.SetAttributes(cassErrMsg(err.Error()))
+	}
+	qs.span.End()
+}
+
+// Page is the page state of an iterator together with the span context of
+// the query which returned it. Both can be stored, for example in the
+// cursor handed to a client, to resume the query later.
+type Page struct {
+	State       []byte
+	SpanContext trace.SpanContext
+}
+
+// PageState returns the page state of iter, returned by q, along with the
+// span context of q if it was started with StartQuery. Queries resuming
+// from it with StartQuery and WithPageState are linked to the span of q.
+func PageState(q *gocql.Query, iter *gocql.Iter) Page {
+	page := Page{State: iter.PageState()}
+	if qs := querySpanFromContext(q.Context()); qs != nil {
+		page.SpanContext = qs.span.SpanContext()
+	}
+	return page
+}
+
+// observe records the attempt of the query whose span is span. The span is
+// ended, unless the attempt failed and the decision of the retry policy is
+// awaited.
+func (qs *querySpan) observe(span trace.Span, observedQuery gocql.ObservedQuery, name, stmt string) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	if !qs.named {
+		qs.named = true
+		qs.span.SetName(name)
+		qs.span.SetAttributes(cassKeyspace(observedQuery.Keyspace), cassStatement(stmt))
+	}
+
+	// An attempt overlapping a previous one is a speculative execution,
+	// otherwise an attempt following a successful one fetches the next page.
+	speculative := false
+	for _, o := range qs.observed {
+		if observedQuery.Start.Before(o.end) && o.start.Before(observedQuery.End) {
+			speculative = true
+			break
+		}
+	}
+	if !speculative && qs.lastOK {
+		qs.page++
+	}
+	attrs := []kv.KeyValue{cassPage(qs.page)}
+	if speculative {
+		attrs = append(attrs, cassSpeculative(true))
+	}
+	span.SetAttributes(attrs...)
+
+	qs.endPending("")
+	qs.attempts++
+	qs.lastOK = observedQuery.Err == nil
+	qs.observed = append(qs.observed, attemptInterval{observedQuery.Start, observedQuery.End})
+
+	if observedQuery.Err == nil {
+		span.End(trace.WithEndTime(observedQuery.End))
+		return
+	}
+	qs.pending, qs.pendingErr, qs.pendingEnd = span, observedQuery.Err, observedQuery.End
+}
+
+// endPending ends the span of the last failed attempt, recording decision
+// if it is not empty. It must be called with qs.mu held.
+func (qs *querySpan) endPending(decision string) {
+	if qs.pending == nil {
+		return
+	}
+	if decision != "" {
+		qs.pending.SetAttributes(cassRetryDecision(decision))
+	}
+	qs.pending.End(trace.WithEndTime(qs.pendingEnd))
+	qs.pending = nil
+}
+
+// tracedRetryPolicy records the decisions of a retry policy on the spans
+// of the failed attempts of queries traced with StartQuery.
+type tracedRetryPolicy struct {
+	gocql.RetryPolicy
+}
+
+// Attempt calls gocql.RetryPolicy.Attempt and records the decision on the
+// span of the failed attempt of q.
+func (p tracedRetryPolicy) Attempt(q gocql.RetryableQuery) bool {
+	ok := p.RetryPolicy.Attempt(q)
+
+	qs := querySpanFromContext(q.Context())
+	if qs == nil {
+		return ok
+	}
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	if qs.pending == nil {
+		return ok
+	}
+	if !ok {
+		qs.endPending("exhausted")
+		return ok
+	}
+	// The decision is then made by GetRetryType, which is only given the
+	// error, so it is evaluated here as well.
+	qs.endPending(retryDecision(p.GetRetryType(qs.pendingErr)))
+	return ok
+}
+
+// retryDecision returns the name of the retry type rt.
+func retryDecision(rt gocql.RetryType) string {
+	switch rt {
+	case gocql.Retry:
+		return "retry"
+	case gocql.RetryNextHost:
+		return "retry_next_host"
+	case gocql.Ignore:
+		return "ignore"
+	case gocql.Rethrow:
+		return "rethrow"
+	}
+	return "unknown"
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gocql
+
+import (
+	"regexp"
+	"strings"
+)
+
+// uuidLiteral matches an unquoted CQL uuid or timeuuid literal.
+var uuidLiteral = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// normalizeStatement returns stmt with its string, numeric, blob and uuid
+// literals replaced by `?` and its whitespace collapsed, so that statements
+// only differing by their values are identical.
+func normalizeStatement(stmt string) string {
+	var b strings.Builder
+	b.Grow(len(stmt))
+
+	// prev is the last byte written, not counting whitespace.
+	var prev byte
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+		prev = s[len(s)-1]
+	}
+
+	for i := 0; i < len(stmt); {
+		c := stmt[i]
+		switch {
+		case isSpace(c):
+			space = true
+			i++
+		case c == '\'':
+			// String literal, where '' is an escaped quote.
+			j := i + 1
+			for j < len(stmt) {
+				if stmt[j] == '\'' {
+					if j+1 < len(stmt) && stmt[j+1] == '\'' {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			write("?")
+			i = j + 1
+		case c == '$' && strings.HasPrefix(stmt[i:], "$$"):
+			// Dollar-quoted string literal.
+			end := strings.Index(stmt[i+2:], "$$")
+			if end < 0 {
+				i = len(stmt)
+			} else {
+				i += end + 4
+			}
+			write("?")
+		case c == '"':
+			// Quoted identifier, kept as is.
+			j := i + 1
+			for j < len(stmt) && stmt[j] != '"' {
+				j++
+			}
+			if j < len(stmt) {
+				j++
+			}
+			write(stmt[i:j])
+			i = j
+		case !isIdent(prev) && uuidLiteral.MatchString(stmt[i:]):
+			write("?")
+			i += len(uuidLiteral.FindString(stmt[i:]))
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(stmt) && isIdent(stmt[j]) {
+				j++
+			}
+			write(stmt[i:j])
+			i = j
+		case isDigit(c) || (c == '-' && i+1 < len(stmt) && isDigit(stmt[i+1]) && !isIdent(prev) && prev != ')'):
+			j := i + 1
+			if c == '0' && j < len(stmt) && (stmt[j] == 'x' || stmt[j] == 'X') {
+				// Blob literal.
+				j++
+				for j < len(stmt) && isHex(stmt[j]) {
+					j++
+				}
+			} else {
+				for j < len(stmt) && (isDigit(stmt[j]) || stmt[j] == '.' || stmt[j] == 'e' || stmt[j] == 'E' ||
+					((stmt[j] == '-' || stmt[j] == '+') && (stmt[j-1] == 'e' || stmt[j-1] == 'E'))) {
+					j++
+				}
+			}
+			write("?")
+			i = j
+		default:
+			write(stmt[i : i+1])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// statementSpanName returns a low cardinality span name for stmt, made of
+// its operation and, if it has one, the table it operates on, such as
+// `SELECT keyspace.table`. Unqualified tables are qualified with keyspace.
+func statementSpanName(stmt, keyspace string) string {
+	words := strings.FieldsFunc(stmt, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '(' || r == ')' || r == ',' || r == ';'
+	})
+	if len(words) == 0 {
+		return cassQueryName
+	}
+
+	// word returns the i-th word, or "" if there is none.
+	word := func(i int) string {
+		if i < len(words) {
+			return words[i]
+		}
+		return ""
+	}
+	// after returns the word following the first keyword kw.
+	after := func(kw string) string {
+		for i, w := range words {
+			if strings.EqualFold(w, kw) {
+				return word(i + 1)
+			}
+		}
+		return ""
+	}
+
+	operation := strings.ToUpper(words[0])
+	var table string
+	switch operation {
+	case "SELECT", "DELETE":
+		table = after("FROM")
+	case "INSERT":
+		table = after("INTO")
+	case "UPDATE":
+		table = word(1)
+	case "BEGIN":
+		return "BATCH"
+	case "USE":
+		return operation + " " + word(1)
+	case "TRUNCATE":
+		table = word(1)
+		if strings.EqualFold(table, "TABLE") {
+			table = word(2)
+		}
+	case "CREATE", "ALTER", "DROP":
+		// Data definition statements, such as CREATE TABLE IF NOT EXISTS t.
+		i := 1
+		for i < len(words) && !isObjectKeyword(words[i]) {
+			i++
+		}
+		if i == len(words) {
+			return operation
+		}
+		object := strings.ToUpper(words[i])
+		operation += " " + object
+		if object == "KEYSPACE" || object == "ROLE" || object == "USER" {
+			// Not scoped to a keyspace.
+			keyspace = ""
+		}
+		for i++; i < len(words); i++ {
+			if w := strings.ToUpper(words[i]); w != "IF" && w != "NOT" && w != "EXISTS" {
+				break
+			}
+		}
+		table = word(i)
+		if strings.EqualFold(table, "ON") {
+			// Unnamed index, named after the table it is created on.
+			table = word(i + 1)
+		}
+	default:
+		return operation
+	}
+
+	if table == "" || table == "?" {
+		return operation
+	}
+	if !strings.Contains(table, ".") && keyspace != "" {
+		table = keyspace + "." + table
+	}
+	return operation + " " + table
+}
+
+// isObjectKeyword returns true if w is the kind of schema object created,
+// altered or dropped by a data definition statement.
+func isObjectKeyword(w string) bool {
+	switch strings.ToUpper(w) {
+	case "KEYSPACE", "TABLE", "COLUMNFAMILY", "INDEX", "TYPE", "FUNCTION", "AGGREGATE",
+		"TRIGGER", "ROLE", "USER", "VIEW":
+		return true
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
@@ -23,6 +23,9 @@ import (
 type Config struct {
 	Tracer      oteltrace.Tracer
 	Propagators otelpropagation.Propagators
+
+	RecoverPanics bool
+	Repanic       bool
 }
 
 // Option specifies instrumentation configuration options.
@@ -46,3 +49,14 @@ func WithPropagators(propagators otelpropagation.Propagators) Option {
 		cfg.Propagators = propagators
 	}
 }
+
+// WithPanicRecovery enables recovery from panics in the routed handler. The
+// panic and its stack trace are recorded on the span. Unless repanic is set,
+// a 500 status is written if the handler had not written a response yet;
+// with repanic the panic continues up to http.Server.
+func WithPanicRecovery(repanic bool) Option {
+	return func(cfg *Config) {
+		cfg.RecoverPanics = true
+		cfg.Repanic = repanic
+	}
+}
@@ -17,10 +17,13 @@ package mux
 import (
 	"fmt"
 	"net/http"
+	"runtime/debug"
 	"sync"
 
 	"github.com/gorilla/mux"
 
+	"go.opentelemetry.io/contrib/internal/panics"
+
 	otelglobal "go.opentelemetry.io/otel/api/global"
 	otelpropagation "go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/standard"
@@ -47,19 +50,23 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 	}
 	return func(handler http.Handler) http.Handler {
 		return traceware{
-			service:     service,
-			tracer:      cfg.Tracer,
-			propagators: cfg.Propagators,
-			handler:     handler,
+			service:       service,
+			tracer:        cfg.Tracer,
+			propagators:   cfg.Propagators,
+			handler:       handler,
+			recoverPanics: cfg.RecoverPanics,
+			repanic:       cfg.Repanic,
 		}
 	}
 }
 
 type traceware struct {
-	service     string
-	tracer      oteltrace.Tracer
-	propagators otelpropagation.Propagators
-	handler     http.Handler
+	service       string
+	tracer        oteltrace.Tracer
+	propagators   otelpropagation.Propagators
+	handler       http.Handler
+	recoverPanics bool
+	repanic       bool
 }
 
 type recordingResponseWriter struct {
@@ -112,18 +119,7 @@ func putRRW(rrw *recordingResponseWriter) {
 // tracing of the request.
 func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := otelpropagation.ExtractHTTP(r.Context(), tw.propagators, r.Header)
-	spanName := ""
-	route := mux.CurrentRoute(r)
-	if route != nil {
-		var err error
-		spanName, err = route.GetPathTemplate()
-		if err != nil {
-			spanName, err = route.GetPathRegexp()
-			if err != nil {
-				spanName = ""
-			}
-		}
-	}
+	spanName := routeTemplate(mux.CurrentRoute(r))
 	routeStr := spanName
 	if spanName == "" {
 		spanName = fmt.Sprintf("HTTP %s route not found", r.Method)
@@ -139,9 +135,60 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	r2 := r.WithContext(ctx)
 	rrw := getRRW(w)
 	defer putRRW(rrw)
+	if tw.recoverPanics {
+		defer func() {
+			if p := recover(); p != nil {
+				if !tw.repanic && !rrw.written {
+					rrw.WriteHeader(http.StatusInternalServerError)
+				}
+				panics.RecordHTTP(ctx, span, p, debug.Stack())
+				if tw.repanic {
+					panic(p)
+				}
+			}
+		}()
+	}
 	tw.handler.ServeHTTP(rrw, r2)
 	attrs := standard.HTTPAttributesFromHTTPStatusCode(rrw.status)
 	spanStatus, spanMessage := standard.SpanStatusFromHTTPStatusCode(rrw.status)
 	span.SetAttributes(attrs...)
 	span.SetStatus(spanStatus, spanMessage)
 }
+
+// routeTemplate returns the path template of route, falling back to its path
+// regexp, or an empty string if route is nil or has neither.
+func routeTemplate(route *mux.Route) string {
+	if route == nil {
+		return ""
+	}
+	if tmpl, err := route.GetPathTemplate(); err == nil {
+		return tmpl
+	}
+	if re, err := route.GetPathRegexp(); err == nil {
+		return re
+	}
+	return ""
+}
+
+// RouteResolver resolves the path template of the route of a mux.Router
+// matching a request. It implements the RouteResolver interface of
+// go.opentelemetry.io/contrib/instrumentation/net/http, so the template can
+// name the spans of an http.Handler wrapping the router.
+type RouteResolver struct {
+	router *mux.Router
+}
+
+// NewRouteResolver returns a RouteResolver for the routes of router.
+func NewRouteResolver(router *mux.Router) RouteResolver {
+	return RouteResolver{router: router}
+}
+
+// ResolveRoute returns the path template of the route matching r, or an
+// empty string if no route matches.
+func (rr RouteResolver) ResolveRoute(r *http.Request) string {
+	var match mux.RouteMatch
+	if !rr.router.Match(r, &match) {
+		return ""
+	}
+	return routeTemplate(match.Route)
+}
@@ -181,3 +181,54 @@ func TestPropagationWithCustomPropagators(t *testing.T) {
 
 	router.ServeHTTP(w, r)
 }
+
+func TestRouteResolver(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/user/{id}", func(http.ResponseWriter, *http.Request) {})
+	rr := NewRouteResolver(router)
+
+	assert.Equal(t, "/user/{id}", rr.ResolveRoute(httptest.NewRequest("GET", "/user/123", nil)))
+	assert.Equal(t, "", rr.ResolveRoute(httptest.NewRequest("GET", "/book/123", nil)))
+}
+
+func TestPanicRecovery(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	router := mux.NewRouter()
+	router.Use(Middleware("foobar", WithTracer(tracer), WithPanicRecovery(false)))
+	router.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("oh no")
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, kv.IntValue(http.StatusInternalServerError), span.Attributes["http.status_code"])
+	assert.Equal(t, "oh no", span.StatusMessage)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+	assert.Equal(t, kv.StringValue("oh no"), span.Events[0].Attributes["exception.message"])
+}
+
+func TestPanicRecoveryRepanic(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	router := mux.NewRouter()
+	router.Use(Middleware("foobar", WithTracer(tracer), WithPanicRecovery(true)))
+	router.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("oh no")
+	})
+	assert.PanicsWithValue(t, "oh no", func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", nil))
+	})
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "oh no", span.StatusMessage)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+}
@@ -23,6 +23,9 @@ import (
 type Config struct {
 	Tracer      oteltrace.Tracer
 	Propagators otelpropagation.Propagators
+
+	RecoverPanics bool
+	Repanic       bool
 }
 
 // Option specifies instrumentation configuration options.
@@ -46,3 +49,14 @@ func WithPropagators(propagators otelpropagation.Propagators) Option {
 		cfg.Propagators = propagators
 	}
 }
+
+// WithPanicRecovery makes the middleware recover from panics in the handler
+// chain and record them on the span as an exception event. If repanic is
+// false the panic value is passed as an error to the echo HTTP error handler,
+// which responds with 500 by default; otherwise it is panicked again.
+func WithPanicRecovery(repanic bool) Option {
+	return func(cfg *Config) {
+		cfg.RecoverPanics = true
+		cfg.Repanic = repanic
+	}
+}
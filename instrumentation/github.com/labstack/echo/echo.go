@@ -16,9 +16,12 @@ package echo
 
 import (
 	"fmt"
+	"runtime/debug"
 
 	"github.com/labstack/echo/v4"
 
+	"go.opentelemetry.io/contrib/internal/panics"
+
 	otelglobal "go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/kv"
 	otelpropagation "go.opentelemetry.io/otel/api/propagation"
@@ -67,6 +70,26 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 			ctx, span := cfg.Tracer.Start(ctx, spanName, opts...)
 			defer span.End()
 
+			if cfg.RecoverPanics {
+				defer func() {
+					if p := recover(); p != nil {
+						if !cfg.Repanic {
+							// invokes the registered HTTP error handler,
+							// which responds with a 500 to plain errors
+							err, ok := p.(error)
+							if !ok {
+								err = fmt.Errorf("%v", p)
+							}
+							c.Error(err)
+						}
+						panics.RecordHTTP(ctx, span, p, debug.Stack())
+						if cfg.Repanic {
+							panic(p)
+						}
+					}
+				}()
+			}
+
 			// pass the span through the request context
 			c.SetRequest(request.WithContext(ctx))
 
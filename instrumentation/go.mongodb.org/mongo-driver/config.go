@@ -15,22 +15,44 @@
 package mongo
 
 import (
+	"time"
+
 	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/trace"
 )
 
 const (
 	defaultTracerName = "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver"
+
+	// DefaultMaxInFlight is the default maximum number of commands in
+	// flight whose spans are held by a monitor.
+	DefaultMaxInFlight = 10000
+	// DefaultInFlightTTL is the default time after which the spans of
+	// commands without a completion reported by the driver are ended.
+	DefaultInFlightTTL = 10 * time.Minute
 )
 
 // Config is used to configure the mongo tracer.
 type Config struct {
 	Tracer trace.Tracer
+	Meter  metric.Meter
+
+	StatementPolicy  StatementPolicy
+	StatementMaxSize int
+
+	MaxInFlight int
+	InFlightTTL time.Duration
 }
 
 // newConfig returns a Config with all Options set.
 func newConfig(opts ...Option) Config {
-	cfg := Config{}
+	cfg := Config{
+		StatementPolicy:  StatementObfuscated,
+		StatementMaxSize: DefaultStatementMaxSize,
+		MaxInFlight:      DefaultMaxInFlight,
+		InFlightTTL:      DefaultInFlightTTL,
+	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
@@ -52,3 +74,53 @@ func WithTracer(tracer trace.Tracer) Option {
 		cfg.Tracer = tracer
 	}
 }
+
+// WithMeter enables metrics, recorded with the provided meter. If this option
+// isn't specified no metrics are recorded.
+func WithMeter(meter metric.Meter) Option {
+	return func(cfg *Config) {
+		cfg.Meter = meter
+	}
+}
+
+// WithStatementPolicy specifies how commands are recorded in the
+// db.statement attribute of spans. If none is specified, StatementObfuscated
+// is used. Authentication commands are never recorded.
+func WithStatementPolicy(policy StatementPolicy) Option {
+	return func(cfg *Config) {
+		cfg.StatementPolicy = policy
+	}
+}
+
+// WithStatementMaxSize specifies the maximum size, in bytes, of the
+// db.statement attribute of spans. Larger statements are truncated. If none
+// is specified, DefaultStatementMaxSize is used. A size of zero or less
+// disables the limit.
+func WithStatementMaxSize(size int) Option {
+	return func(cfg *Config) {
+		cfg.StatementMaxSize = size
+	}
+}
+
+// WithMaxInFlight specifies the maximum number of commands in flight whose
+// spans are held by the monitor. The spans of commands started beyond it are
+// ended immediately with an error status, and their metrics are only
+// labelled with the database system and operation. If none is specified,
+// DefaultMaxInFlight is used. A maximum of zero or less disables the limit.
+func WithMaxInFlight(max int) Option {
+	return func(cfg *Config) {
+		cfg.MaxInFlight = max
+	}
+}
+
+// WithInFlightTTL specifies the time after which the span of a command whose
+// completion was never reported by the driver, for instance because its
+// connection was closed, is ended with an error status. Such spans are
+// swept by a goroutine of the monitor, which runs while commands are in
+// flight. If none is specified, DefaultInFlightTTL is used. A TTL of zero or
+// less disables it.
+func WithInFlightTTL(ttl time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.InFlightTTL = ttl
+	}
+}
@@ -23,6 +23,12 @@ const (
 	DBInstanceKey    = kv.Key("db.instance")
 	DBUserKey        = kv.Key("db.user")
 	DBStatementKey   = kv.Key("db.statement")
+	DBOperationKey   = kv.Key("db.operation")
+	DBCollectionKey  = kv.Key("db.mongodb.collection")
+
+	PoolReasonKey       = kv.Key("db.mongodb.pool.reason")
+	ServerKindKey       = kv.Key("db.mongodb.server.kind")
+	HeartbeatAwaitedKey = kv.Key("db.mongodb.heartbeat.awaited")
 )
 
 // DBApplication indicates the application using the database.
@@ -54,3 +60,30 @@ func DBUser(dbUser string) kv.KeyValue {
 func DBStatement(dbStatement string) kv.KeyValue {
 	return DBStatementKey.String(dbStatement)
 }
+
+// DBOperation indicates the name of the command, e.g. "find" or "insert".
+func DBOperation(dbOperation string) kv.KeyValue {
+	return DBOperationKey.String(dbOperation)
+}
+
+// DBCollection indicates the collection a command operates on.
+func DBCollection(dbCollection string) kv.KeyValue {
+	return DBCollectionKey.String(dbCollection)
+}
+
+// PoolReason indicates why a connection was closed or could not be checked
+// out of the pool, e.g. "idle" or "timeout".
+func PoolReason(reason string) kv.KeyValue {
+	return PoolReasonKey.String(reason)
+}
+
+// ServerKind indicates the kind of a server, e.g. "RSPrimary" or "Unknown".
+func ServerKind(kind string) kv.KeyValue {
+	return ServerKindKey.String(kind)
+}
+
+// HeartbeatAwaited indicates whether a heartbeat was an awaited streaming
+// heartbeat, whose duration includes the time waited for a change.
+func HeartbeatAwaited(awaited bool) kv.KeyValue {
+	return HeartbeatAwaitedKey.Bool(awaited)
+}
@@ -17,7 +17,24 @@
 // It support v0.2.0 of github.com/mongodb/mongo-go-driver
 //
 // `NewMonitor` will return an event.CommandMonitor which is used to trace
-// requests.
+// requests. Spans are named after the command and its collection, such as
+// "insert users", and metrics of command durations, errors and operations
+// per collection are recorded when the `WithMeter` option is used.
+//
+// Commands are recorded in the db.statement attribute with their values
+// replaced by "?" and cut to `DefaultStatementMaxSize` bytes. This is
+// configured with the `WithStatementPolicy` and `WithStatementMaxSize`
+// options. Authentication commands are never recorded.
+//
+// The spans of commands in flight are held by the monitor until the driver
+// reports their completion. Spans held for longer than `DefaultInFlightTTL`,
+// or started beyond `DefaultMaxInFlight` commands in flight, are ended with
+// an error status. This is configured with the `WithInFlightTTL` and
+// `WithMaxInFlight` options.
+//
+// `NewPoolMonitor` and `NewServerMonitor` return an event.PoolMonitor and an
+// event.ServerMonitor recording connection pool metrics, server heartbeat
+// metrics and spans of failed heartbeats.
 //
 // This code was originally based on the following:
 // - https://github.com/DataDog/dd-trace-go/tree/02f0449efa3cb382d499fadc873957385dcb2192/contrib/go.mongodb.org/mongo-driver/mongo
@@ -28,6 +28,8 @@ func Example() {
 	// connect to MongoDB
 	opts := options.Client()
 	opts.Monitor = mongotrace.NewMonitor("test-service")
+	opts.PoolMonitor = mongotrace.NewPoolMonitor()
+	opts.ServerMonitor = mongotrace.NewServerMonitor()
 	opts.ApplyURI("mongodb://localhost:27017")
 	client, err := mongo.Connect(context.Background(), opts)
 	if err != nil {
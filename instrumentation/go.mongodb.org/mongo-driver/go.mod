@@ -7,8 +7,8 @@ replace go.opentelemetry.io/contrib => ../../..
 require (
 	github.com/stretchr/testify v1.6.1
 	github.com/xdg/stringprep v1.0.0 // indirect
-	go.mongodb.org/mongo-driver v1.4.0
+	go.mongodb.org/mongo-driver v1.5.0
 	go.opentelemetry.io/contrib v0.10.0
 	go.opentelemetry.io/otel v0.10.0
-	golang.org/x/crypto v0.0.0-20191105034135-c7e5f84aec59 // indirect
+	google.golang.org/grpc v1.31.0
 )
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/standard"
+)
+
+// Command metrics
+const (
+	CommandDuration   = "db.mongodb.command.duration"   // Time from a command being started to its completion, microseconds
+	CommandErrors     = "db.mongodb.command.errors"     // Commands that failed
+	CommandOperations = "db.mongodb.operations"         // Commands completed, per operation and collection
+	CommandsInFlight  = "db.mongodb.commands.in_flight" // Commands started and not completed
+)
+
+// Connection pool metrics
+const (
+	PoolConnectionsCreated = "db.mongodb.pool.connections.created" // Connections created
+	PoolConnectionsClosed  = "db.mongodb.pool.connections.closed"  // Connections closed, per reason
+	PoolConnectionsOpen    = "db.mongodb.pool.connections.open"    // Connections open
+	PoolConnectionsInUse   = "db.mongodb.pool.connections.in_use"  // Connections checked out of the pool
+	PoolCheckouts          = "db.mongodb.pool.checkouts"           // Connections checked out of the pool
+	PoolCheckoutFailures   = "db.mongodb.pool.checkout_failures"   // Failures to check a connection out of the pool, per reason
+	PoolUseDuration        = "db.mongodb.pool.use_duration"        // Time from a connection being checked out to it being checked in, microseconds
+	PoolClears             = "db.mongodb.pool.clears"              // Pools cleared
+)
+
+// Server metrics
+const (
+	HeartbeatDuration = "db.mongodb.heartbeat.duration"  // Time taken by server heartbeats, microseconds
+	HeartbeatFailures = "db.mongodb.heartbeat.failures"  // Server heartbeats that failed
+	ServerKindChanges = "db.mongodb.server.kind_changes" // Changes of the kind of a server, labelled with the new kind
+)
+
+func handleErr(err error) {
+	if err != nil {
+		global.Handle(err)
+	}
+}
+
+// commandMetrics holds the command instruments. A nil *commandMetrics
+// records nothing.
+type commandMetrics struct {
+	duration   metric.Int64ValueRecorder
+	errors     metric.Int64Counter
+	operations metric.Int64Counter
+}
+
+func newCommandMetrics(meter metric.Meter) *commandMetrics {
+	if meter.MeterImpl() == nil {
+		return nil
+	}
+
+	m := &commandMetrics{}
+	var err error
+
+	m.duration, err = meter.NewInt64ValueRecorder(CommandDuration)
+	handleErr(err)
+
+	m.errors, err = meter.NewInt64Counter(CommandErrors)
+	handleErr(err)
+
+	m.operations, err = meter.NewInt64Counter(CommandOperations)
+	handleErr(err)
+
+	return m
+}
+
+// observeInFlight registers an observer of the number of commands in flight
+// in m.
+func (cm *commandMetrics) observeInFlight(meter metric.Meter, m *monitor) {
+	if cm == nil {
+		return
+	}
+
+	_, err := meter.NewInt64ValueObserver(CommandsInFlight, func(ctx context.Context, result metric.Int64ObserverResult) {
+		result.Observe(m.spans.len(), standard.DBSystemMongodb)
+	})
+	handleErr(err)
+}
+
+// record records the completion of a command labelled with labels, which
+// took duration and failed if err is not nil.
+func (m *commandMetrics) record(ctx context.Context, labels []kv.KeyValue, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.operations.Add(ctx, 1, labels...)
+	m.duration.Record(ctx, duration.Microseconds(), labels...)
+	if err != nil {
+		m.errors.Add(ctx, 1, labels...)
+	}
+}
+
+// poolMetrics holds the connection pool instruments. A nil *poolMetrics
+// records nothing.
+type poolMetrics struct {
+	created          metric.Int64Counter
+	closed           metric.Int64Counter
+	open             metric.Int64UpDownCounter
+	inUse            metric.Int64UpDownCounter
+	checkouts        metric.Int64Counter
+	checkoutFailures metric.Int64Counter
+	useDuration      metric.Int64ValueRecorder
+	clears           metric.Int64Counter
+}
+
+func newPoolMetrics(meter metric.Meter) *poolMetrics {
+	if meter.MeterImpl() == nil {
+		return nil
+	}
+
+	m := &poolMetrics{}
+	var err error
+
+	m.created, err = meter.NewInt64Counter(PoolConnectionsCreated)
+	handleErr(err)
+
+	m.closed, err = meter.NewInt64Counter(PoolConnectionsClosed)
+	handleErr(err)
+
+	m.open, err = meter.NewInt64UpDownCounter(PoolConnectionsOpen)
+	handleErr(err)
+
+	m.inUse, err = meter.NewInt64UpDownCounter(PoolConnectionsInUse)
+	handleErr(err)
+
+	m.checkouts, err = meter.NewInt64Counter(PoolCheckouts)
+	handleErr(err)
+
+	m.checkoutFailures, err = meter.NewInt64Counter(PoolCheckoutFailures)
+	handleErr(err)
+
+	m.useDuration, err = meter.NewInt64ValueRecorder(PoolUseDuration)
+	handleErr(err)
+
+	m.clears, err = meter.NewInt64Counter(PoolClears)
+	handleErr(err)
+
+	return m
+}
+
+// serverMetrics holds the server instruments. A nil *serverMetrics records
+// nothing.
+type serverMetrics struct {
+	heartbeatDuration metric.Int64ValueRecorder
+	heartbeatFailures metric.Int64Counter
+	kindChanges       metric.Int64Counter
+}
+
+func newServerMetrics(meter metric.Meter) *serverMetrics {
+	if meter.MeterImpl() == nil {
+		return nil
+	}
+
+	m := &serverMetrics{}
+	var err error
+
+	m.heartbeatDuration, err = meter.NewInt64ValueRecorder(HeartbeatDuration)
+	handleErr(err)
+
+	m.heartbeatFailures, err = meter.NewInt64Counter(HeartbeatFailures)
+	handleErr(err)
+
+	m.kindChanges, err = meter.NewInt64Counter(ServerKindChanges)
+	handleErr(err)
+
+	return m
+}
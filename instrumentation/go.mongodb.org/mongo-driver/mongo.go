@@ -16,93 +16,189 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
-	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
 
 	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
 	"go.opentelemetry.io/otel/api/trace"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/event"
 )
 
+var (
+	errTooManyInFlight = errors.New("too many commands in flight")
+	errNoCompletion    = errors.New("no completion reported for the command")
+)
+
 type spanKey struct {
 	ConnectionID string
 	RequestID    int64
 }
 
+// commandSpan is the span of a command in flight, with the labels of the
+// metrics recorded when it completes.
+type commandSpan struct {
+	span    trace.Span
+	labels  []kv.KeyValue
+	started time.Time
+}
+
 type monitor struct {
-	sync.Mutex
-	spans       map[spanKey]trace.Span
+	spans       *spanStore
 	serviceName string
 	cfg         Config
+	metrics     *commandMetrics
+	// sweeping is 1 while the sweeper goroutine runs, updated atomically.
+	sweeping int32
 }
 
 func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
-	hostname, port := peerInfo(evt)
-	b, _ := bson.MarshalExtJSON(evt.Command, false, false)
+	hostname, port := peerInfo(evt.ConnectionID)
+	collection := commandCollection(evt.Command)
+	labels := []kv.KeyValue{
+		standard.DBSystemMongodb,
+		DBName(evt.DatabaseName),
+		DBOperation(evt.CommandName),
+	}
+	if collection != "" {
+		labels = append(labels, DBCollection(collection))
+	}
 	attrs := []kv.KeyValue{
 		ServiceName(m.serviceName),
 		ResourceName("mongo." + evt.CommandName),
 		DBInstance(evt.DatabaseName),
-		DBStatement(string(b)),
 		DBType("mongo"),
 		PeerHostname(hostname),
 		PeerPort(port),
 	}
+	if stmt, ok := m.cfg.statement(evt.CommandName, evt.Command); ok {
+		attrs = append(attrs, DBStatement(stmt))
+	}
+	attrs = append(attrs, labels...)
+	attrs = append(attrs, netPeerAttributes(hostname, port)...)
 	opts := []trace.StartOption{
 		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindClient),
 	}
-	_, span := m.cfg.Tracer.Start(ctx, "mongodb.query", opts...)
+	_, span := m.cfg.Tracer.Start(ctx, spanName(evt.CommandName, collection), opts...)
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
-	m.Lock()
-	m.spans[key] = span
-	m.Unlock()
+	if !m.spans.add(key, commandSpan{span: span, labels: labels, started: time.Now()}) {
+		m.end(span, codes.ResourceExhausted, errTooManyInFlight)
+		return
+	}
+	m.startSweeper()
+}
+
+// startSweeper starts the goroutine sweeping the spans of expired commands,
+// unless it is running or the in-flight TTL is disabled. The goroutine stops
+// once no command is in flight, and the next command starts it again.
+func (m *monitor) startSweeper() {
+	if m.cfg.InFlightTTL <= 0 || !atomic.CompareAndSwapInt32(&m.sweeping, 0, 1) {
+		return
+	}
+	go m.runSweeper()
+}
+
+func (m *monitor) runSweeper() {
+	period := m.cfg.InFlightTTL / 2
+	if period <= 0 {
+		period = m.cfg.InFlightTTL
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		m.sweep(context.Background(), now)
+		if m.spans.len() > 0 {
+			continue
+		}
+		atomic.StoreInt32(&m.sweeping, 0)
+		// Commands started before the flag was cleared did not start a
+		// sweeper, so keep sweeping if there are any.
+		if m.spans.len() == 0 || !atomic.CompareAndSwapInt32(&m.sweeping, 0, 1) {
+			return
+		}
+	}
+}
+
+// sweep ends the spans of the commands started more than the in-flight TTL
+// before now, for which the driver never reported a completion.
+func (m *monitor) sweep(ctx context.Context, now time.Time) {
+	for _, cs := range m.spans.expire(now) {
+		m.metrics.record(ctx, cs.labels, now.Sub(cs.started), errNoCompletion)
+		m.end(cs.span, codes.DeadlineExceeded, errNoCompletion)
+	}
+}
+
+// end ends span with the status code and the error attributes of err.
+func (m *monitor) end(span trace.Span, code codes.Code, err error) {
+	span.SetAttributes(Error(true))
+	span.SetAttributes(ErrorMsg(err.Error()))
+	span.SetStatus(code, err.Error())
+	span.End()
 }
 
 func (m *monitor) Succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
-	m.Finished(&evt.CommandFinishedEvent, nil)
+	m.Finished(ctx, &evt.CommandFinishedEvent, nil)
 }
 
 func (m *monitor) Failed(ctx context.Context, evt *event.CommandFailedEvent) {
-	m.Finished(&evt.CommandFinishedEvent, fmt.Errorf("%s", evt.Failure))
+	m.Finished(ctx, &evt.CommandFinishedEvent, fmt.Errorf("%s", evt.Failure))
 }
 
-func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
+func (m *monitor) Finished(ctx context.Context, evt *event.CommandFinishedEvent, err error) {
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
-	m.Lock()
-	span, ok := m.spans[key]
-	if ok {
-		delete(m.spans, key)
-	}
-	m.Unlock()
+	duration := time.Duration(evt.DurationNanos)
+	cs, ok := m.spans.remove(key)
 	if !ok {
+		// The command was started beyond the in-flight limit, so only the
+		// labels known from evt are available. Commands that lasted the
+		// in-flight TTL may have been swept, and thus already recorded.
+		if m.cfg.InFlightTTL <= 0 || duration < m.cfg.InFlightTTL {
+			m.metrics.record(ctx, []kv.KeyValue{standard.DBSystemMongodb, DBOperation(evt.CommandName)}, duration, err)
+		}
 		return
 	}
 
+	m.metrics.record(ctx, cs.labels, duration, err)
+
 	if err != nil {
-		span.SetAttributes(Error(true))
-		span.SetAttributes(ErrorMsg(err.Error()))
+		m.end(cs.span, codes.Unknown, err)
+		return
 	}
 
-	span.End()
+	cs.span.End()
 }
 
-// NewMonitor creates a new mongodb event CommandMonitor.
-func NewMonitor(serviceName string, opts ...Option) *event.CommandMonitor {
+func newMonitor(serviceName string, opts ...Option) *monitor {
 	cfg := newConfig(opts...)
 	m := &monitor{
-		spans:       make(map[spanKey]trace.Span),
+		spans:       newSpanStore(cfg.MaxInFlight, cfg.InFlightTTL),
 		serviceName: serviceName,
 		cfg:         cfg,
+		metrics:     newCommandMetrics(cfg.Meter),
 	}
+	m.metrics.observeInFlight(cfg.Meter, m)
+	return m
+}
+
+// NewMonitor creates a new mongodb event CommandMonitor.
+func NewMonitor(serviceName string, opts ...Option) *event.CommandMonitor {
+	m := newMonitor(serviceName, opts...)
 	return &event.CommandMonitor{
 		Started:   m.Started,
 		Succeeded: m.Succeeded,
@@ -110,8 +206,10 @@ func NewMonitor(serviceName string, opts ...Option) *event.CommandMonitor {
 	}
 }
 
-func peerInfo(evt *event.CommandStartedEvent) (hostname, port string) {
-	hostname = evt.ConnectionID
+// peerInfo returns the host name and port of a connection ID or address
+// reported by the driver, such as "localhost:27017[-1]".
+func peerInfo(connectionID string) (hostname, port string) {
+	hostname = connectionID
 	port = "27017"
 	if idx := strings.IndexByte(hostname, '['); idx >= 0 {
 		hostname = hostname[:idx]
@@ -122,3 +220,76 @@ func peerInfo(evt *event.CommandStartedEvent) (hostname, port string) {
 	}
 	return hostname, port
 }
+
+// netPeerAttributes returns the net.peer attributes of the peer at hostname
+// and port, as returned by peerInfo.
+func netPeerAttributes(hostname, port string) []kv.KeyValue {
+	var attrs []kv.KeyValue
+	if ip := net.ParseIP(hostname); ip != nil {
+		attrs = append(attrs, standard.NetPeerIPKey.String(ip.String()))
+	} else {
+		attrs = append(attrs, standard.NetPeerNameKey.String(hostname))
+	}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, standard.NetPeerPortKey.Int(p))
+	}
+	return attrs
+}
+
+// collectionCommands are the commands bound to a collection, which they
+// name in their first element, such as {"insert": "coll"}. The first
+// element of other commands, such as {"createUser": "name"}, names
+// something else.
+var collectionCommands = map[string]bool{
+	"aggregate":     true,
+	"collmod":       true,
+	"collstats":     true,
+	"compact":       true,
+	"count":         true,
+	"create":        true,
+	"createindexes": true,
+	"delete":        true,
+	"distinct":      true,
+	"drop":          true,
+	"dropindexes":   true,
+	"find":          true,
+	"findandmodify": true,
+	"insert":        true,
+	"killcursors":   true,
+	"listindexes":   true,
+	"mapreduce":     true,
+	"reindex":       true,
+	"update":        true,
+	"validate":      true,
+}
+
+// commandCollection returns the collection a command operates on, or an
+// empty string for commands that are not bound to a collection. Collection
+// commands name their collection in their first element, while getMore
+// names it in its "collection" element.
+func commandCollection(cmd bson.Raw) string {
+	elem, err := cmd.IndexErr(0)
+	if err != nil {
+		return ""
+	}
+	command := strings.ToLower(elem.Key())
+	if command == "getmore" {
+		collection, _ := cmd.Lookup("collection").StringValueOK()
+		return collection
+	}
+	if !collectionCommands[command] {
+		return ""
+	}
+	// Commands such as {"aggregate": 1} run on the database.
+	collection, _ := elem.Value().StringValueOK()
+	return collection
+}
+
+// spanName returns the name of the span of a command, "<command>
+// <collection>", or only the command name when it has no collection.
+func spanName(command, collection string) string {
+	if collection == "" {
+		return command
+	}
+	return command + " " + collection
+}
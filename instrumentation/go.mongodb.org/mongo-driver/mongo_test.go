@@ -20,14 +20,23 @@ import (
 	"testing"
 	"time"
 
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
 	mocktracer "go.opentelemetry.io/contrib/internal/trace"
 	"go.opentelemetry.io/contrib/internal/util"
 
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMain(m *testing.M) {
@@ -66,11 +75,109 @@ func Test(t *testing.T) {
 	assert.Equal(t, spans[0].SpanContext().TraceID, spans[1].SpanContext().TraceID)
 
 	s := spans[0]
+	assert.Equal(t, "insert test-collection", s.Name)
+	assert.Equal(t, trace.SpanKindClient, s.Kind)
+	assert.Equal(t, "mongodb", s.Attributes[standard.DBSystemKey].AsString())
+	assert.Equal(t, "test-database", s.Attributes[DBNameKey].AsString())
+	assert.Equal(t, "insert", s.Attributes[DBOperationKey].AsString())
+	assert.Equal(t, "test-collection", s.Attributes[DBCollectionKey].AsString())
+	assert.Equal(t, hostname, s.Attributes[standard.NetPeerNameKey].AsString())
+	assert.Equal(t, int64(27017), s.Attributes[standard.NetPeerPortKey].AsInt64())
 	assert.Equal(t, "mongo", s.Attributes[ServiceNameKey].AsString())
 	assert.Equal(t, "mongo.insert", s.Attributes[ResourceNameKey].AsString())
 	assert.Equal(t, hostname, s.Attributes[PeerHostnameKey].AsString())
 	assert.Equal(t, port, s.Attributes[PeerPortKey].AsString())
-	assert.Contains(t, s.Attributes[DBStatementKey].AsString(), `"test-item":"test-value"`)
+	assert.Contains(t, s.Attributes[DBStatementKey].AsString(), `"test-item":"?"`)
 	assert.Equal(t, "test-database", s.Attributes[DBInstanceKey].AsString())
 	assert.Equal(t, "mongo", s.Attributes[DBTypeKey].AsString())
 }
+
+func startedEvent(t *testing.T, requestID int64, command string, cmd bson.D) *event.CommandStartedEvent {
+	raw, err := bson.Marshal(cmd)
+	require.NoError(t, err)
+	return &event.CommandStartedEvent{
+		Command:      raw,
+		DatabaseName: "test-database",
+		CommandName:  command,
+		RequestID:    requestID,
+		ConnectionID: "10.0.0.1:27018[-1]",
+	}
+}
+
+func TestMonitorMetrics(t *testing.T) {
+	mt := mocktracer.NewTracer("mongodb")
+	meterimpl, meter := mockmeter.NewMeter()
+	monitor := NewMonitor("mongo", WithTracer(mt), WithMeter(meter))
+	ctx := context.Background()
+
+	monitor.Started(ctx, startedEvent(t, 1, "find", bson.D{{Key: "find", Value: "test-collection"}}))
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			DurationNanos: int64(2 * time.Millisecond),
+			CommandName:   "find",
+			RequestID:     1,
+			ConnectionID:  "10.0.0.1:27018[-1]",
+		},
+	})
+	monitor.Started(ctx, startedEvent(t, 2, "getMore", bson.D{
+		{Key: "getMore", Value: int64(42)},
+		{Key: "collection", Value: "test-collection"},
+	}))
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			DurationNanos: int64(time.Millisecond),
+			CommandName:   "getMore",
+			RequestID:     2,
+			ConnectionID:  "10.0.0.1:27018[-1]",
+		},
+		Failure: "cursor not found",
+	})
+	monitor.Started(ctx, startedEvent(t, 3, "ping", bson.D{{Key: "ping", Value: 1}}))
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "find test-collection", spans[0].Name)
+	assert.Equal(t, "10.0.0.1", spans[0].Attributes[standard.NetPeerIPKey].AsString())
+	assert.Equal(t, int64(27018), spans[0].Attributes[standard.NetPeerPortKey].AsInt64())
+	assert.Equal(t, "getMore test-collection", spans[1].Name)
+	assert.Equal(t, codes.Unknown, spans[1].Status)
+	assert.True(t, spans[1].Attributes[ErrorKey].AsBool())
+
+	got := map[string][]int64{}
+	for _, batch := range meterimpl.MeasurementBatches {
+		assert.Equal(t, []kv.KeyValue{
+			standard.DBSystemMongodb,
+			DBName("test-database"),
+			DBOperation(batch.Labels[2].Value.AsString()),
+			DBCollection("test-collection"),
+		}, batch.Labels)
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			got[name] = append(got[name], m.Number.AsInt64())
+		}
+	}
+	assert.Equal(t, []int64{1, 1}, got[CommandOperations])
+	assert.Equal(t, []int64{2000, 1000}, got[CommandDuration])
+	assert.Equal(t, []int64{1}, got[CommandErrors])
+}
+
+func TestSpanName(t *testing.T) {
+	for _, tc := range []struct {
+		command string
+		cmd     bson.D
+		want    string
+	}{
+		{"insert", bson.D{{Key: "insert", Value: "coll"}}, "insert coll"},
+		{"getMore", bson.D{{Key: "getMore", Value: int64(1)}, {Key: "collection", Value: "coll"}}, "getMore coll"},
+		{"ping", bson.D{{Key: "ping", Value: 1}}, "ping"},
+		{"createUser", bson.D{{Key: "createUser", Value: "alice"}}, "createUser"},
+		{"dropUser", bson.D{{Key: "dropUser", Value: "alice"}}, "dropUser"},
+		{"aggregate", bson.D{{Key: "aggregate", Value: 1}}, "aggregate"},
+		{"findAndModify", bson.D{{Key: "findAndModify", Value: "coll"}}, "findAndModify coll"},
+		{"ping", bson.D{}, "ping"},
+	} {
+		raw, err := bson.Marshal(tc.cmd)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, spanName(tc.command, commandCollection(raw)))
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
+	mocktracer "go.opentelemetry.io/contrib/internal/trace"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// measurements returns the values recorded by meterimpl per instrument,
+// with the labels of their batch.
+func measurements(meterimpl *mockmeter.MeterImpl) (map[string][]int64, map[string][][]kv.KeyValue) {
+	values := map[string][]int64{}
+	labels := map[string][][]kv.KeyValue{}
+	for _, batch := range meterimpl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			values[name] = append(values[name], m.Number.AsInt64())
+			labels[name] = append(labels[name], batch.Labels)
+		}
+	}
+	return values, labels
+}
+
+func TestPoolMonitor(t *testing.T) {
+	meterimpl, meter := mockmeter.NewMeter()
+	monitor := NewPoolMonitor(WithMeter(meter))
+
+	const addr = "db.example.com:27018"
+	for _, evt := range []*event.PoolEvent{
+		{Type: event.PoolCreated, Address: addr},
+		{Type: event.ConnectionCreated, Address: addr, ConnectionID: 1},
+		{Type: event.ConnectionCreated, Address: addr, ConnectionID: 2},
+		{Type: event.GetSucceeded, Address: addr, ConnectionID: 1},
+		{Type: event.GetSucceeded, Address: addr, ConnectionID: 2},
+		{Type: event.ConnectionReturned, Address: addr, ConnectionID: 1},
+		{Type: event.GetFailed, Address: addr, Reason: event.ReasonTimedOut},
+		{Type: event.ConnectionClosed, Address: addr, ConnectionID: 2, Reason: event.ReasonConnectionErrored},
+		{Type: event.ConnectionClosed, Address: addr, ConnectionID: 1, Reason: event.ReasonPoolClosed},
+		{Type: event.PoolCleared, Address: addr},
+	} {
+		monitor.Event(evt)
+	}
+
+	values, labels := measurements(meterimpl)
+	assert.Equal(t, []int64{1, 1}, values[PoolConnectionsCreated])
+	assert.Equal(t, []int64{1, 1, -1, -1}, values[PoolConnectionsOpen])
+	assert.Equal(t, []int64{1, 1}, values[PoolCheckouts])
+	assert.Equal(t, []int64{1, 1, -1, -1}, values[PoolConnectionsInUse], "closing a connection in use checks it in")
+	assert.Len(t, values[PoolUseDuration], 2)
+	assert.Equal(t, []int64{1}, values[PoolCheckoutFailures])
+	assert.Equal(t, []int64{1}, values[PoolClears])
+
+	server := []kv.KeyValue{
+		standard.DBSystemMongodb,
+		standard.NetPeerNameKey.String("db.example.com"),
+		standard.NetPeerPortKey.Int(27018),
+	}
+	assert.Equal(t, server, labels[PoolCheckouts][0])
+	assert.Equal(t, append(server, PoolReason(event.ReasonTimedOut)), labels[PoolCheckoutFailures][0])
+	assert.Equal(t, [][]kv.KeyValue{
+		append(server, PoolReason(event.ReasonConnectionErrored)),
+		append(server, PoolReason(event.ReasonPoolClosed)),
+	}, labels[PoolConnectionsClosed])
+}
+
+func TestServerMonitor(t *testing.T) {
+	mt := mocktracer.NewTracer("mongodb")
+	meterimpl, meter := mockmeter.NewMeter()
+	monitor := NewServerMonitor(WithTracer(mt), WithMeter(meter))
+
+	const conn = "10.0.0.1:27017[-1]"
+	monitor.ServerHeartbeatSucceeded(&event.ServerHeartbeatSucceededEvent{
+		DurationNanos: int64(time.Millisecond),
+		ConnectionID:  conn,
+		Awaited:       true,
+	})
+	monitor.ServerHeartbeatFailed(&event.ServerHeartbeatFailedEvent{
+		DurationNanos: int64(2 * time.Millisecond),
+		Failure:       errors.New("connection refused"),
+		ConnectionID:  conn,
+	})
+	monitor.ServerDescriptionChanged(&event.ServerDescriptionChangedEvent{
+		Address:             address.Address("10.0.0.1:27017"),
+		PreviousDescription: description.Server{Kind: description.RSSecondary},
+		NewDescription:      description.Server{Kind: description.Unknown},
+	})
+	monitor.ServerDescriptionChanged(&event.ServerDescriptionChangedEvent{
+		Address:             address.Address("10.0.0.1:27017"),
+		PreviousDescription: description.Server{Kind: description.Unknown},
+		NewDescription:      description.Server{Kind: description.Unknown},
+	})
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 1, "only failed heartbeats are traced")
+	s := spans[0]
+	assert.Equal(t, heartbeatSpanName, s.Name)
+	assert.Equal(t, trace.SpanKindClient, s.Kind)
+	assert.Equal(t, codes.Unavailable, s.Status)
+	assert.Equal(t, "connection refused", s.StatusMessage)
+	assert.Equal(t, "10.0.0.1", s.Attributes[standard.NetPeerIPKey].AsString())
+	assert.False(t, s.Attributes[HeartbeatAwaitedKey].AsBool())
+
+	values, labels := measurements(meterimpl)
+	assert.Equal(t, []int64{1000, 2000}, values[HeartbeatDuration])
+	assert.Equal(t, []int64{1}, values[HeartbeatFailures])
+	assert.Equal(t, []int64{1}, values[ServerKindChanges])
+	assert.Equal(t, []kv.KeyValue{
+		standard.DBSystemMongodb,
+		standard.NetPeerIPKey.String("10.0.0.1"),
+		standard.NetPeerPortKey.Int(27017),
+		ServerKind("Unknown"),
+	}, labels[ServerKindChanges][0])
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type connectionKey struct {
+	Address      string
+	ConnectionID uint64
+}
+
+type poolMonitor struct {
+	sync.Mutex
+	// checkouts holds the check-out time of the connections in use.
+	checkouts map[connectionKey]time.Time
+	metrics   *poolMetrics
+}
+
+// serverLabels returns the labels of the metrics of the server at address.
+func serverLabels(address string) []kv.KeyValue {
+	return append([]kv.KeyValue{standard.DBSystemMongodb}, netPeerAttributes(peerInfo(address))...)
+}
+
+func (m *poolMonitor) Event(evt *event.PoolEvent) {
+	if m.metrics == nil {
+		return
+	}
+
+	ctx := context.Background()
+	labels := serverLabels(evt.Address)
+	key := connectionKey{Address: evt.Address, ConnectionID: evt.ConnectionID}
+	switch evt.Type {
+	case event.ConnectionCreated:
+		m.metrics.created.Add(ctx, 1, labels...)
+		m.metrics.open.Add(ctx, 1, labels...)
+	case event.ConnectionClosed:
+		m.metrics.closed.Add(ctx, 1, append(labels, PoolReason(evt.Reason))...)
+		m.metrics.open.Add(ctx, -1, labels...)
+		m.checkIn(ctx, key, labels)
+	case event.GetSucceeded:
+		m.Lock()
+		m.checkouts[key] = time.Now()
+		m.Unlock()
+		m.metrics.checkouts.Add(ctx, 1, labels...)
+		m.metrics.inUse.Add(ctx, 1, labels...)
+	case event.GetFailed:
+		m.metrics.checkoutFailures.Add(ctx, 1, append(labels, PoolReason(evt.Reason))...)
+	case event.ConnectionReturned:
+		m.checkIn(ctx, key, labels)
+	case event.PoolCleared:
+		m.metrics.clears.Add(ctx, 1, labels...)
+	}
+}
+
+// checkIn records the use of the connection identified by key if it is
+// checked out of the pool.
+func (m *poolMonitor) checkIn(ctx context.Context, key connectionKey, labels []kv.KeyValue) {
+	m.Lock()
+	start, ok := m.checkouts[key]
+	if ok {
+		delete(m.checkouts, key)
+	}
+	m.Unlock()
+	if !ok {
+		return
+	}
+
+	m.metrics.inUse.Add(ctx, -1, labels...)
+	m.metrics.useDuration.Record(ctx, time.Since(start).Microseconds(), labels...)
+}
+
+// NewPoolMonitor creates a new mongodb event PoolMonitor. It records the
+// connections created, closed, open and in use, the connections checked
+// out of the pool, how long they are used, check-out failures and pool
+// clears with the meter specified with WithMeter. Pool events carry no
+// context, so no spans are recorded.
+//
+// The time spent waiting for a connection is not recorded: the driver only
+// reports the end of a check-out, with GetSucceeded or GetFailed, and has no
+// event marking its start.
+func NewPoolMonitor(opts ...Option) *event.PoolMonitor {
+	cfg := newConfig(opts...)
+	m := &poolMonitor{
+		checkouts: make(map[connectionKey]time.Time),
+		metrics:   newPoolMetrics(cfg.Meter),
+	}
+	return &event.PoolMonitor{
+		Event: m.Event,
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/trace"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+const heartbeatSpanName = "mongodb.heartbeat"
+
+type serverMonitor struct {
+	cfg     Config
+	metrics *serverMetrics
+}
+
+func (m *serverMonitor) HeartbeatSucceeded(evt *event.ServerHeartbeatSucceededEvent) {
+	if m.metrics == nil {
+		return
+	}
+	labels := append(serverLabels(evt.ConnectionID), HeartbeatAwaited(evt.Awaited))
+	m.metrics.heartbeatDuration.Record(context.Background(), time.Duration(evt.DurationNanos).Microseconds(), labels...)
+}
+
+// HeartbeatFailed records a failed heartbeat as a span, started when the
+// heartbeat was sent, with the failure as an error event.
+func (m *serverMonitor) HeartbeatFailed(evt *event.ServerHeartbeatFailedEvent) {
+	ctx := context.Background()
+	labels := append(serverLabels(evt.ConnectionID), HeartbeatAwaited(evt.Awaited))
+	if m.metrics != nil {
+		m.metrics.heartbeatDuration.Record(ctx, time.Duration(evt.DurationNanos).Microseconds(), labels...)
+		m.metrics.heartbeatFailures.Add(ctx, 1, labels...)
+	}
+
+	end := time.Now()
+	_, span := m.cfg.Tracer.Start(ctx, heartbeatSpanName,
+		trace.WithStartTime(end.Add(-time.Duration(evt.DurationNanos))),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(labels...),
+	)
+	if evt.Failure != nil {
+		span.RecordError(ctx, evt.Failure, trace.WithErrorTime(end))
+		span.SetStatus(codes.Unavailable, evt.Failure.Error())
+	} else {
+		span.SetStatus(codes.Unavailable, "heartbeat failed")
+	}
+	span.End(trace.WithEndTime(end))
+}
+
+func (m *serverMonitor) ServerDescriptionChanged(evt *event.ServerDescriptionChangedEvent) {
+	if m.metrics == nil || evt.PreviousDescription.Kind == evt.NewDescription.Kind {
+		return
+	}
+	labels := append(serverLabels(evt.Address.String()), ServerKind(evt.NewDescription.Kind.String()))
+	m.metrics.kindChanges.Add(context.Background(), 1, labels...)
+}
+
+// NewServerMonitor creates a new mongodb event ServerMonitor. It records the
+// duration of server heartbeats, heartbeat failures and changes of the kind
+// of servers, such as a secondary becoming primary or a server becoming
+// unknown, with the meter specified with WithMeter. Failed heartbeats are
+// also recorded as spans.
+func NewServerMonitor(opts ...Option) *event.ServerMonitor {
+	cfg := newConfig(opts...)
+	m := &serverMonitor{
+		cfg:     cfg,
+		metrics: newServerMetrics(cfg.Meter),
+	}
+	return &event.ServerMonitor{
+		ServerHeartbeatSucceeded: m.HeartbeatSucceeded,
+		ServerHeartbeatFailed:    m.HeartbeatFailed,
+		ServerDescriptionChanged: m.ServerDescriptionChanged,
+	}
+}
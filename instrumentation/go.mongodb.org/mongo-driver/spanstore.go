@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const spanStoreShards = 32
+
+// spanStore holds the spans of the commands in flight. It holds at most max
+// spans, counted across all its shards, which only spread the locking.
+type spanStore struct {
+	shards [spanStoreShards]spanShard
+	// size is the number of spans stored, updated atomically.
+	size int64
+	max  int64
+	ttl  time.Duration
+	// lastSweep is the time of the last sweep, in Unix nanoseconds.
+	lastSweep int64
+}
+
+type spanShard struct {
+	sync.Mutex
+	spans map[spanKey]commandSpan
+}
+
+func newSpanStore(maxSize int, ttl time.Duration) *spanStore {
+	s := &spanStore{
+		max:       int64(maxSize),
+		ttl:       ttl,
+		lastSweep: time.Now().UnixNano(),
+	}
+	for i := range s.shards {
+		s.shards[i].spans = make(map[spanKey]commandSpan)
+	}
+	return s
+}
+
+func (s *spanStore) shard(key spanKey) *spanShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.ConnectionID))
+	return &s.shards[(h.Sum32()^uint32(key.RequestID))%spanStoreShards]
+}
+
+// add stores cs under key, replacing the span already stored under key if
+// any. It returns false, storing nothing, if the store is full.
+func (s *spanStore) add(key spanKey, cs commandSpan) bool {
+	shard := s.shard(key)
+	shard.Lock()
+	defer shard.Unlock()
+	if _, ok := shard.spans[key]; !ok {
+		if n := atomic.AddInt64(&s.size, 1); s.max > 0 && n > s.max {
+			atomic.AddInt64(&s.size, -1)
+			return false
+		}
+	}
+	shard.spans[key] = cs
+	return true
+}
+
+// remove removes and returns the span stored under key.
+func (s *spanStore) remove(key spanKey) (commandSpan, bool) {
+	shard := s.shard(key)
+	shard.Lock()
+	cs, ok := shard.spans[key]
+	if ok {
+		delete(shard.spans, key)
+		atomic.AddInt64(&s.size, -1)
+	}
+	shard.Unlock()
+	return cs, ok
+}
+
+// len returns the number of spans stored.
+func (s *spanStore) len() int64 {
+	return atomic.LoadInt64(&s.size)
+}
+
+// expire removes and returns the spans started more than the TTL before
+// now. It does nothing, returning nil, if the TTL is zero or less or if the
+// last sweep was less than half the TTL before now, so that it can be
+// called as often as needed.
+func (s *spanStore) expire(now time.Time) []commandSpan {
+	if s.ttl <= 0 {
+		return nil
+	}
+	last := atomic.LoadInt64(&s.lastSweep)
+	if now.UnixNano()-last < int64(s.ttl/2) || !atomic.CompareAndSwapInt64(&s.lastSweep, last, now.UnixNano()) {
+		return nil
+	}
+
+	var expired []commandSpan
+	deadline := now.Add(-s.ttl)
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.Lock()
+		for key, cs := range shard.spans {
+			if cs.started.Before(deadline) {
+				expired = append(expired, cs)
+				delete(shard.spans, key)
+				atomic.AddInt64(&s.size, -1)
+			}
+		}
+		shard.Unlock()
+	}
+	return expired
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
+	mocktracer "go.opentelemetry.io/contrib/internal/trace"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+)
+
+func TestSpanStore(t *testing.T) {
+	store := newSpanStore(2, time.Minute)
+	start := time.Now()
+
+	key := spanKey{ConnectionID: "localhost:27017[-1]", RequestID: 1}
+	require.True(t, store.add(key, commandSpan{started: start}))
+	require.True(t, store.add(spanKey{ConnectionID: key.ConnectionID, RequestID: 2}, commandSpan{started: start}))
+	assert.False(t, store.add(spanKey{ConnectionID: key.ConnectionID, RequestID: 3}, commandSpan{}), "the store holds two spans")
+	assert.True(t, store.add(key, commandSpan{started: start}), "a stored span can be replaced")
+	assert.Equal(t, int64(2), store.len())
+
+	_, ok := store.remove(key)
+	assert.True(t, ok)
+	_, ok = store.remove(key)
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), store.len())
+
+	require.True(t, store.add(key, commandSpan{started: start}))
+	assert.Empty(t, store.expire(start.Add(20*time.Second)), "sweeps are at least half the TTL apart")
+	assert.Empty(t, store.expire(start.Add(40*time.Second)), "the spans have not expired")
+	assert.Len(t, store.expire(start.Add(2*time.Minute)), 2)
+	assert.Equal(t, int64(0), store.len())
+}
+
+func TestSpanStoreConcurrency(t *testing.T) {
+	store := newSpanStore(0, time.Minute)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(conn string) {
+			defer wg.Done()
+			for id := int64(0); id < 1000; id++ {
+				key := spanKey{ConnectionID: conn, RequestID: id}
+				assert.True(t, store.add(key, commandSpan{started: time.Now()}))
+				_, ok := store.remove(key)
+				assert.True(t, ok)
+			}
+		}(fmt.Sprintf("localhost:27017[-%d]", i))
+	}
+	wg.Wait()
+	assert.Equal(t, int64(0), store.len())
+}
+
+func TestMonitorInFlight(t *testing.T) {
+	mt := mocktracer.NewTracer("mongodb")
+	meterimpl, meter := mockmeter.NewMeter()
+	m := newMonitor("mongo", WithTracer(mt), WithMeter(meter), WithMaxInFlight(1), WithInFlightTTL(time.Minute))
+	ctx := context.Background()
+
+	started := func(requestID int64) *event.CommandStartedEvent {
+		raw, err := bson.Marshal(bson.D{{Key: "find", Value: "coll"}})
+		require.NoError(t, err)
+		return &event.CommandStartedEvent{
+			Command:      raw,
+			DatabaseName: "test-database",
+			CommandName:  "find",
+			RequestID:    requestID,
+			ConnectionID: "localhost:27017[-1]",
+		}
+	}
+
+	m.Started(ctx, started(1))
+	m.Started(ctx, started(2))
+
+	meterimpl.RunAsyncInstruments()
+	values, _ := measurements(meterimpl)
+	assert.Equal(t, []int64{1}, values[CommandsInFlight])
+
+	spans := mt.EndedSpans()
+	require.Len(t, spans, 1, "the span of a command beyond the limit is ended")
+	assert.Equal(t, codes.ResourceExhausted, spans[0].Status)
+
+	m.sweep(ctx, time.Now().Add(2*time.Minute))
+	spans = mt.EndedSpans()
+	require.Len(t, spans, 1, "the span of an orphaned command is ended")
+	assert.Equal(t, codes.DeadlineExceeded, spans[0].Status)
+	assert.True(t, spans[0].Attributes[ErrorKey].AsBool())
+	assert.Equal(t, int64(0), m.spans.len())
+
+	values, _ = measurements(meterimpl)
+	assert.Equal(t, []int64{1}, values[CommandErrors])
+
+	finished := func(requestID int64, duration time.Duration) *event.CommandFinishedEvent {
+		return &event.CommandFinishedEvent{
+			DurationNanos: int64(duration),
+			CommandName:   "find",
+			RequestID:     requestID,
+			ConnectionID:  "localhost:27017[-1]",
+		}
+	}
+	meterimpl.MeasurementBatches = nil
+	m.Finished(ctx, finished(2, time.Millisecond), nil)
+	values, labels := measurements(meterimpl)
+	assert.Equal(t, []int64{1}, values[CommandOperations], "a command beyond the limit is recorded")
+	assert.Equal(t, []int64{1000}, values[CommandDuration])
+	assert.Equal(t, [][]kv.KeyValue{{standard.DBSystemMongodb, DBOperation("find")}}, labels[CommandOperations])
+
+	meterimpl.MeasurementBatches = nil
+	m.Finished(ctx, finished(1, 2*time.Minute), nil)
+	values, _ = measurements(meterimpl)
+	assert.Empty(t, values, "a swept command is not recorded twice")
+}
+
+func TestMonitorSweeper(t *testing.T) {
+	mt := mocktracer.NewTracer("mongodb")
+	m := newMonitor("mongo", WithTracer(mt), WithInFlightTTL(10*time.Millisecond))
+
+	raw, err := bson.Marshal(bson.D{{Key: "find", Value: "coll"}})
+	require.NoError(t, err)
+	m.Started(context.Background(), &event.CommandStartedEvent{
+		Command:      raw,
+		DatabaseName: "test-database",
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	assert.Equal(t, int32(1), atomic.LoadInt32(&m.sweeping))
+
+	var spans []*mocktracer.Span
+	require.Eventually(t, func() bool {
+		spans = append(spans, mt.EndedSpans()...)
+		return len(spans) > 0
+	}, time.Second, 5*time.Millisecond, "the span of the orphaned command is ended in the background")
+	assert.Equal(t, codes.DeadlineExceeded, spans[0].Status)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&m.sweeping) == 0
+	}, time.Second, 5*time.Millisecond, "the sweeper stops once no command is in flight")
+}
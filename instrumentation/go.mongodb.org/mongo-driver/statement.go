@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// StatementPolicy specifies how commands are recorded in the db.statement
+// attribute of spans.
+type StatementPolicy int
+
+const (
+	// StatementObfuscated records commands with their values replaced by
+	// "?". The first element of a command, which names the command, keeps
+	// its value if it is a string, usually the collection. This is the
+	// default.
+	StatementObfuscated StatementPolicy = iota
+	// StatementFull records commands with their values.
+	StatementFull
+	// StatementOff records no statement.
+	StatementOff
+)
+
+// DefaultStatementMaxSize is the default maximum size, in bytes, of a
+// recorded statement.
+const DefaultStatementMaxSize = 4096
+
+// authCommands are the commands carrying credentials, which are never
+// recorded.
+var authCommands = map[string]bool{
+	"authenticate":    true,
+	"saslstart":       true,
+	"saslcontinue":    true,
+	"getnonce":        true,
+	"createuser":      true,
+	"updateuser":      true,
+	"copydbgetnonce":  true,
+	"copydbsaslstart": true,
+	"copydb":          true,
+}
+
+// isAuthCommand returns whether the command named command, with document
+// cmd, carries credentials. This includes isMaster commands performing
+// speculative authentication.
+func isAuthCommand(command string, cmd bson.Raw) bool {
+	command = strings.ToLower(command)
+	if authCommands[command] {
+		return true
+	}
+	if command != "ismaster" {
+		return false
+	}
+	_, err := cmd.LookupErr("speculativeAuthenticate")
+	return err == nil
+}
+
+// statement returns the db.statement of the command named command, with
+// document cmd, according to the statement policy and maximum size of cfg.
+// It returns false if no statement is recorded.
+//
+// The command is reduced to the values fitting in the maximum size, and
+// obfuscated, before it is marshalled, so that large commands such as
+// insert batches are not marshalled in full.
+func (cfg Config) statement(command string, cmd bson.Raw) (string, bool) {
+	if cfg.StatementPolicy == StatementOff || len(cmd) == 0 || isAuthCommand(command, cmd) {
+		return "", false
+	}
+
+	r := reducer{
+		budget:    cfg.StatementMaxSize,
+		obfuscate: cfg.StatementPolicy == StatementObfuscated,
+	}
+	if r.budget <= 0 {
+		r.budget = math.MaxInt32
+	}
+	b, err := bson.MarshalExtJSON(r.command(cmd), false, false)
+	if err != nil {
+		return "", false
+	}
+	return truncate(string(b), cfg.StatementMaxSize), true
+}
+
+// reducer copies commands, keeping approximately budget bytes of their
+// keys and values.
+type reducer struct {
+	budget    int
+	obfuscate bool
+}
+
+// command returns the reduced copy of cmd. The value of its first element is
+// not obfuscated if it is a string, which names the collection of most
+// commands. Other values, such as the command explained by explain, are
+// reduced like the rest of the command.
+func (r *reducer) command(cmd bson.Raw) bson.D {
+	elems, err := cmd.Elements()
+	if err != nil || len(elems) == 0 {
+		return bson.D{}
+	}
+	d := make(bson.D, 0, len(elems))
+	first := elems[0]
+	r.budget -= len(first.Key())
+	if collection, ok := first.Value().StringValueOK(); ok {
+		r.budget -= len(collection)
+		d = append(d, bson.E{Key: first.Key(), Value: collection})
+	} else {
+		d = append(d, bson.E{Key: first.Key(), Value: r.value(first.Value())})
+	}
+	return append(d, r.elements(elems[1:])...)
+}
+
+func (r *reducer) elements(elems []bson.RawElement) bson.D {
+	d := make(bson.D, 0, len(elems))
+	for _, elem := range elems {
+		if r.budget <= 0 {
+			break
+		}
+		r.budget -= len(elem.Key())
+		d = append(d, bson.E{Key: elem.Key(), Value: r.value(elem.Value())})
+	}
+	return d
+}
+
+func (r *reducer) value(v bson.RawValue) interface{} {
+	switch v.Type {
+	case bsontype.EmbeddedDocument:
+		elems, err := v.Document().Elements()
+		if err != nil {
+			return bson.D{}
+		}
+		return r.elements(elems)
+	case bsontype.Array:
+		vals, err := v.Array().Values()
+		if err != nil {
+			return bson.A{}
+		}
+		a := make(bson.A, 0, len(vals))
+		for _, val := range vals {
+			if r.budget <= 0 {
+				break
+			}
+			a = append(a, r.value(val))
+		}
+		return a
+	}
+	if r.obfuscate {
+		r.budget--
+		return "?"
+	}
+	r.budget -= len(v.Value)
+	return v
+}
+
+// truncate returns s cut to at most max bytes, without splitting a
+// character. A max of zero or less leaves s unchanged.
+func truncate(s string, max int) string {
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatement(t *testing.T) {
+	insert := bson.D{
+		{Key: "insert", Value: "coll"},
+		{Key: "documents", Value: bson.A{
+			bson.D{{Key: "name", Value: "alice"}, {Key: "age", Value: 30}},
+		}},
+		{Key: "ordered", Value: true},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		command string
+		cmd     bson.D
+		opts    []Option
+		want    string
+		ok      bool
+	}{
+		{
+			name:    "obfuscated by default",
+			command: "insert",
+			cmd:     insert,
+			want:    `{"insert":"coll","documents":[{"name":"?","age":"?"}],"ordered":"?"}`,
+			ok:      true,
+		},
+		{
+			name:    "full",
+			command: "insert",
+			cmd:     insert,
+			opts:    []Option{WithStatementPolicy(StatementFull)},
+			want:    `{"insert":"coll","documents":[{"name":"alice","age":30}],"ordered":true}`,
+			ok:      true,
+		},
+		{
+			name:    "off",
+			command: "insert",
+			cmd:     insert,
+			opts:    []Option{WithStatementPolicy(StatementOff)},
+		},
+		{
+			name:    "authentication",
+			command: "saslStart",
+			cmd:     bson.D{{Key: "saslStart", Value: 1}, {Key: "payload", Value: "secret"}},
+			opts:    []Option{WithStatementPolicy(StatementFull)},
+		},
+		{
+			name:    "speculative authentication",
+			command: "isMaster",
+			cmd:     bson.D{{Key: "isMaster", Value: 1}, {Key: "speculativeAuthenticate", Value: bson.D{}}},
+			opts:    []Option{WithStatementPolicy(StatementFull)},
+		},
+		{
+			name:    "explain",
+			command: "explain",
+			cmd: bson.D{{Key: "explain", Value: bson.D{
+				{Key: "find", Value: "c"},
+				{Key: "filter", Value: bson.D{{Key: "ssn", Value: "123"}}},
+			}}},
+			want: `{"explain":{"find":"?","filter":{"ssn":"?"}}}`,
+			ok:   true,
+		},
+		{
+			name:    "numeric command value",
+			command: "ping",
+			cmd:     bson.D{{Key: "ping", Value: 1}},
+			want:    `{"ping":"?"}`,
+			ok:      true,
+		},
+		{
+			name:    "truncated",
+			command: "insert",
+			cmd:     insert,
+			opts:    []Option{WithStatementPolicy(StatementFull), WithStatementMaxSize(20)},
+			want:    `{"insert":"coll","do`,
+			ok:      true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := bson.Marshal(tc.cmd)
+			require.NoError(t, err)
+			got, ok := newConfig(tc.opts...).statement(tc.command, raw)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStatementReducesLargeCommands(t *testing.T) {
+	docs := make(bson.A, 10000)
+	for i := range docs {
+		docs[i] = bson.D{{Key: "value", Value: strings.Repeat("x", 100)}}
+	}
+	raw, err := bson.Marshal(bson.D{{Key: "insert", Value: "coll"}, {Key: "documents", Value: docs}})
+	require.NoError(t, err)
+
+	r := reducer{budget: DefaultStatementMaxSize}
+	cmd := r.command(raw)
+	require.Len(t, cmd, 2)
+	assert.Less(t, len(cmd[1].Value.(bson.A)), 100, "values beyond the maximum size are not copied")
+
+	got, ok := newConfig(WithStatementPolicy(StatementFull)).statement("insert", raw)
+	assert.True(t, ok)
+	assert.Len(t, got, DefaultStatementMaxSize)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 0))
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	assert.Equal(t, "a", truncate("aé", 2), "characters are not split")
+}
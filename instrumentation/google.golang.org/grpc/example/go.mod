@@ -2,7 +2,10 @@ module go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/exampl
 
 go 1.14
 
-replace go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc => ../
+replace (
+	go.opentelemetry.io/contrib => ../../../../
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc => ../
+)
 
 require (
 	github.com/golang/protobuf v1.4.2
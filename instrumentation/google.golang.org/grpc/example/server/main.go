@@ -22,7 +22,6 @@ import (
 	"net"
 	"time"
 
-	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/example/grpc/api"
 	"go.opentelemetry.io/otel/example/grpc/config"
 
@@ -118,8 +117,8 @@ func main() {
 	}
 
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(grpcotel.UnaryServerInterceptor(global.Tracer(""))),
-		grpc.StreamInterceptor(grpcotel.StreamServerInterceptor(global.Tracer(""))),
+		grpc.UnaryInterceptor(grpcotel.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(grpcotel.StreamServerInterceptor()),
 	)
 
 	api.RegisterHelloServiceServer(s, &server{})
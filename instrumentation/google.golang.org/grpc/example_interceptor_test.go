@@ -15,16 +15,17 @@
 package grpc
 
 import (
+	"strings"
+
 	"google.golang.org/grpc"
 
 	"go.opentelemetry.io/otel/api/global"
 )
 
 func ExampleStreamClientInterceptor() {
-	tracer := global.Tracer("client-instrumentation")
 	_, _ = grpc.Dial(
 		"localhost",
-		grpc.WithStreamInterceptor(StreamClientInterceptor(tracer)),
+		grpc.WithStreamInterceptor(StreamClientInterceptor()),
 	)
 }
 
@@ -32,20 +33,25 @@ func ExampleUnaryClientInterceptor() {
 	tracer := global.Tracer("client-instrumentation")
 	_, _ = grpc.Dial(
 		"localhost",
-		grpc.WithUnaryInterceptor(UnaryClientInterceptor(tracer)),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(WithTracer(tracer))),
 	)
 }
 
 func ExampleStreamServerInterceptor() {
-	tracer := global.Tracer("server-instrumentation")
 	_ = grpc.NewServer(
-		grpc.StreamInterceptor(StreamServerInterceptor(tracer)),
+		grpc.StreamInterceptor(StreamServerInterceptor(
+			WithTracerProvider(global.TraceProvider()),
+		)),
 	)
 }
 
 func ExampleUnaryServerInterceptor() {
-	tracer := global.Tracer("server-instrumentation")
 	_ = grpc.NewServer(
-		grpc.UnaryInterceptor(UnaryServerInterceptor(tracer)),
+		grpc.UnaryInterceptor(UnaryServerInterceptor(
+			// Do not trace health checks.
+			WithFilter(func(info *InterceptorInfo) bool {
+				return !strings.HasPrefix(info.Method, "/grpc.health.v1.Health/")
+			}),
+		)),
 	)
 }
@@ -22,16 +22,28 @@ import (
 	"go.opentelemetry.io/otel/api/correlation"
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/trace"
 )
 
+// instrumentationName is the name of this instrumentation package.
+const instrumentationName = "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc"
+
 // Option is a function that allows configuration of the grpc Extract()
-// and Inject() functions
+// and Inject() functions and of the interceptors
 type Option func(*config)
 
 type config struct {
-	propagators propagation.Propagators
+	propagators       propagation.Propagators
+	meter             metric.Meter
+	tracer            trace.Tracer
+	provider          trace.Provider
+	filters           []Filter
+	spanNameFormatter func(*InterceptorInfo, string) string
+	spanStartOptions  []trace.StartOption
+	messagePolicy     *MessagePolicy
+	samplingHint      SamplingHint
 }
 
 func newConfig(opts []Option) *config {
@@ -42,6 +54,48 @@ func newConfig(opts []Option) *config {
 	return c
 }
 
+// newInterceptorConfig returns the config of an interceptor, with the tracer
+// resolved from the configured provider unless one was set directly.
+func newInterceptorConfig(opts []Option) *config {
+	c := newConfig(opts)
+	if c.tracer == nil {
+		if c.provider == nil {
+			c.provider = global.TraceProvider()
+		}
+		c.tracer = c.provider.Tracer(instrumentationName)
+	}
+	return c
+}
+
+// shouldTrace reports whether every configured filter accepts info.
+func (c *config) shouldTrace(info *InterceptorInfo) bool {
+	for _, f := range c.filters {
+		if !f(info) {
+			return false
+		}
+	}
+	return true
+}
+
+// spanName returns the name of the span for info, where name is the default
+// name derived from the full method.
+func (c *config) spanName(info *InterceptorInfo, name string) string {
+	if c.spanNameFormatter == nil {
+		return name
+	}
+	return c.spanNameFormatter(info, name)
+}
+
+// startOptions returns the options used to start a span of the given kind,
+// followed by the configured span start options and then by extra.
+func (c *config) startOptions(kind trace.SpanKind, attrs []kv.KeyValue, extra ...trace.StartOption) []trace.StartOption {
+	opts := append([]trace.StartOption{
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(attrs...),
+	}, c.spanStartOptions...)
+	return append(opts, extra...)
+}
+
 // WithPropagators sets the propagators to use for Extraction and Injection
 func WithPropagators(props propagation.Propagators) Option {
 	return func(c *config) {
@@ -49,6 +103,57 @@ func WithPropagators(props propagation.Propagators) Option {
 	}
 }
 
+// WithTracer configures the interceptors to use a specific tracer. It takes
+// precedence over WithTracerProvider.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
+// WithTracerProvider configures the interceptors to create their tracer from
+// the provided trace.Provider. If neither this option nor WithTracer is
+// specified then the global trace provider is used.
+func WithTracerProvider(provider trace.Provider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// WithFilter adds a filter to the list of filters used by the interceptors.
+// An RPC is only traced and measured if every filter accepts it. Filters are
+// invoked for each RPC, it is advised to make them simple and fast.
+func WithFilter(f Filter) Option {
+	return func(c *config) {
+		c.filters = append(c.filters, f)
+	}
+}
+
+// WithSpanNameFormatter takes a function that will be called for every RPC
+// with its information and the default span name, and whose returned string
+// becomes the span name.
+func WithSpanNameFormatter(f func(info *InterceptorInfo, name string) string) Option {
+	return func(c *config) {
+		c.spanNameFormatter = f
+	}
+}
+
+// WithSpanOptions configures an additional set of trace.StartOptions, which
+// are applied to each new span.
+func WithSpanOptions(opts ...trace.StartOption) Option {
+	return func(c *config) {
+		c.spanStartOptions = append(c.spanStartOptions, opts...)
+	}
+}
+
+// WithMeter enables RPC metrics in the interceptors, recorded with the
+// provided meter. If this option isn't specified no metrics are recorded.
+func WithMeter(meter metric.Meter) Option {
+	return func(c *config) {
+		c.meter = meter
+	}
+}
+
 type metadataSupplier struct {
 	metadata *metadata.MD
 }
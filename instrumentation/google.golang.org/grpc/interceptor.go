@@ -38,21 +38,13 @@ import (
 type messageType kv.KeyValue
 
 // Event adds an event of the messageType to the span associated with the
-// passed context with id and size (if message is a proto message).
-func (m messageType) Event(ctx context.Context, id int, message interface{}) {
+// passed context with id and the attributes of the message, as returned by
+// MessagePolicy.attributes.
+func (m messageType) Event(ctx context.Context, id int, attrs ...kv.KeyValue) {
 	span := trace.SpanFromContext(ctx)
-	if p, ok := message.(proto.Message); ok {
-		span.AddEvent(ctx, "message",
-			kv.KeyValue(m),
-			standard.RPCMessageIDKey.Int(id),
-			standard.RPCMessageUncompressedSizeKey.Int(proto.Size(p)),
-		)
-	} else {
-		span.AddEvent(ctx, "message",
-			kv.KeyValue(m),
-			standard.RPCMessageIDKey.Int(id),
-		)
-	}
+	span.AddEvent(ctx, "message",
+		append([]kv.KeyValue{kv.KeyValue(m), standard.RPCMessageIDKey.Int(id)}, attrs...)...,
+	)
 }
 
 var (
@@ -62,7 +54,10 @@ var (
 
 // UnaryClientInterceptor returns a grpc.UnaryClientInterceptor suitable
 // for use in a grpc.Dial call.
-func UnaryClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.UnaryClientInterceptor {
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	cfg := newInterceptorConfig(opts)
+	metrics := newClientMetrics(cfg.meter)
+
 	return func(
 		ctx context.Context,
 		method string,
@@ -71,32 +66,46 @@ func UnaryClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.UnaryClien
 		invoker grpc.UnaryInvoker,
 		callOpts ...grpc.CallOption,
 	) error {
+		info := &InterceptorInfo{Method: method, Type: UnaryClient}
+		if !cfg.shouldTrace(info) {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+
 		requestMetadata, _ := metadata.FromOutgoingContext(ctx)
+		samplingOpts, ok := cfg.samplingOptions(info, requestMetadata)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
 		metadataCopy := requestMetadata.Copy()
 
 		name, attr := spanInfo(method, cc.Target())
 		var span trace.Span
-		ctx, span = tracer.Start(
+		ctx, span = cfg.tracer.Start(
 			ctx,
-			name,
-			trace.WithSpanKind(trace.SpanKindClient),
-			trace.WithAttributes(attr...),
+			cfg.spanName(info, name),
+			cfg.startOptions(trace.SpanKindClient, attr, samplingOpts...)...,
 		)
 		defer span.End()
 
 		Inject(ctx, &metadataCopy, opts...)
 		ctx = metadata.NewOutgoingContext(ctx, metadataCopy)
 
-		messageSent.Event(ctx, 1, req)
+		rec := metrics.start(method)
+
+		messageSent.Event(ctx, 1, cfg.messagePolicy.attributes(method, req)...)
+		rec.request(ctx, req)
 
 		err := invoker(ctx, method, req, reply, cc, callOpts...)
 
-		messageReceived.Event(ctx, 1, reply)
+		messageReceived.Event(ctx, 1, cfg.messagePolicy.attributes(method, reply)...)
 
 		if err != nil {
 			s, _ := status.FromError(err)
 			span.SetStatus(s.Code(), s.Message())
+		} else {
+			rec.response(ctx, reply)
 		}
+		rec.end(ctx, err)
 
 		return err
 	}
@@ -124,6 +133,9 @@ type clientStream struct {
 	events     chan streamEvent
 	eventsDone chan struct{}
 	finished   chan error
+	rec        *rpcRecorder
+	method     string
+	policy     *MessagePolicy
 
 	receivedMessageID int
 	sentMessageID     int
@@ -134,6 +146,10 @@ var _ = proto.Marshal
 func (w *clientStream) RecvMsg(m interface{}) error {
 	err := w.ClientStream.RecvMsg(m)
 
+	if err == nil {
+		w.rec.response(w.Context(), m)
+	}
+
 	if err == nil && !w.desc.ServerStreams {
 		w.sendStreamEvent(receiveEndEvent, nil)
 	} else if err == io.EOF {
@@ -142,7 +158,7 @@ func (w *clientStream) RecvMsg(m interface{}) error {
 		w.sendStreamEvent(errorEvent, err)
 	} else {
 		w.receivedMessageID++
-		messageReceived.Event(w.Context(), w.receivedMessageID, m)
+		messageReceived.Event(w.Context(), w.receivedMessageID, w.policy.attributes(w.method, m)...)
 	}
 
 	return err
@@ -152,10 +168,12 @@ func (w *clientStream) SendMsg(m interface{}) error {
 	err := w.ClientStream.SendMsg(m)
 
 	w.sentMessageID++
-	messageSent.Event(w.Context(), w.sentMessageID, m)
+	messageSent.Event(w.Context(), w.sentMessageID, w.policy.attributes(w.method, m)...)
 
 	if err != nil {
 		w.sendStreamEvent(errorEvent, err)
+	} else {
+		w.rec.request(w.Context(), m)
 	}
 
 	return err
@@ -188,7 +206,7 @@ const (
 	receiveEndedState
 )
 
-func wrapClientStream(s grpc.ClientStream, desc *grpc.StreamDesc) *clientStream {
+func wrapClientStream(s grpc.ClientStream, desc *grpc.StreamDesc, method string, cfg *config, rec *rpcRecorder) *clientStream {
 	events := make(chan streamEvent)
 	eventsDone := make(chan struct{})
 	finished := make(chan error)
@@ -223,6 +241,9 @@ func wrapClientStream(s grpc.ClientStream, desc *grpc.StreamDesc) *clientStream
 		events:       events,
 		eventsDone:   eventsDone,
 		finished:     finished,
+		rec:          rec,
+		method:       method,
+		policy:       cfg.messagePolicy,
 	}
 }
 
@@ -235,7 +256,10 @@ func (w *clientStream) sendStreamEvent(eventType streamEventType, err error) {
 
 // StreamClientInterceptor returns a grpc.StreamClientInterceptor suitable
 // for use in a grpc.Dial call.
-func StreamClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamClientInterceptor {
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	cfg := newInterceptorConfig(opts)
+	metrics := newClientMetrics(cfg.meter)
+
 	return func(
 		ctx context.Context,
 		desc *grpc.StreamDesc,
@@ -244,23 +268,33 @@ func StreamClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamCli
 		streamer grpc.Streamer,
 		callOpts ...grpc.CallOption,
 	) (grpc.ClientStream, error) {
+		info := &InterceptorInfo{Method: method, Type: StreamClient}
+		if !cfg.shouldTrace(info) {
+			return streamer(ctx, desc, cc, method, callOpts...)
+		}
+
 		requestMetadata, _ := metadata.FromOutgoingContext(ctx)
+		samplingOpts, ok := cfg.samplingOptions(info, requestMetadata)
+		if !ok {
+			return streamer(ctx, desc, cc, method, callOpts...)
+		}
 		metadataCopy := requestMetadata.Copy()
 
 		name, attr := spanInfo(method, cc.Target())
 		var span trace.Span
-		ctx, span = tracer.Start(
+		ctx, span = cfg.tracer.Start(
 			ctx,
-			name,
-			trace.WithSpanKind(trace.SpanKindClient),
-			trace.WithAttributes(attr...),
+			cfg.spanName(info, name),
+			cfg.startOptions(trace.SpanKindClient, attr, samplingOpts...)...,
 		)
 
 		Inject(ctx, &metadataCopy, opts...)
 		ctx = metadata.NewOutgoingContext(ctx, metadataCopy)
 
+		rec := metrics.start(method)
+
 		s, err := streamer(ctx, desc, cc, method, callOpts...)
-		stream := wrapClientStream(s, desc)
+		stream := wrapClientStream(s, desc, method, cfg, rec)
 
 		go func() {
 			if err == nil {
@@ -271,6 +305,7 @@ func StreamClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamCli
 				s, _ := status.FromError(err)
 				span.SetStatus(s.Code(), s.Message())
 			}
+			rec.end(ctx, err)
 
 			span.End()
 		}()
@@ -281,14 +316,26 @@ func StreamClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamCli
 
 // UnaryServerInterceptor returns a grpc.UnaryServerInterceptor suitable
 // for use in a grpc.NewServer call.
-func UnaryServerInterceptor(tracer trace.Tracer, opts ...Option) grpc.UnaryServerInterceptor {
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	cfg := newInterceptorConfig(opts)
+	metrics := newServerMetrics(cfg.meter)
+
 	return func(
 		ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
+		i := &InterceptorInfo{Method: info.FullMethod, UnaryServerInfo: info, Type: UnaryServer}
+		if !cfg.shouldTrace(i) {
+			return handler(ctx, req)
+		}
+
 		requestMetadata, _ := metadata.FromIncomingContext(ctx)
+		samplingOpts, ok := cfg.samplingOptions(i, requestMetadata)
+		if !ok {
+			return handler(ctx, req)
+		}
 		metadataCopy := requestMetadata.Copy()
 
 		entries, spanCtx := Extract(ctx, &metadataCopy, opts...)
@@ -297,24 +344,28 @@ func UnaryServerInterceptor(tracer trace.Tracer, opts ...Option) grpc.UnaryServe
 		}))
 
 		name, attr := spanInfo(info.FullMethod, peerFromCtx(ctx))
-		ctx, span := tracer.Start(
+		ctx, span := cfg.tracer.Start(
 			trace.ContextWithRemoteSpanContext(ctx, spanCtx),
-			name,
-			trace.WithSpanKind(trace.SpanKindServer),
-			trace.WithAttributes(attr...),
+			cfg.spanName(i, name),
+			cfg.startOptions(trace.SpanKindServer, attr, samplingOpts...)...,
 		)
 		defer span.End()
 
-		messageReceived.Event(ctx, 1, req)
+		rec := metrics.start(info.FullMethod)
+
+		messageReceived.Event(ctx, 1, cfg.messagePolicy.attributes(info.FullMethod, req)...)
+		rec.request(ctx, req)
 
 		resp, err := handler(ctx, req)
 		if err != nil {
 			s, _ := status.FromError(err)
 			span.SetStatus(s.Code(), s.Message())
-			messageSent.Event(ctx, 1, s.Proto())
+			messageSent.Event(ctx, 1, cfg.messagePolicy.attributes(info.FullMethod, s.Proto())...)
 		} else {
-			messageSent.Event(ctx, 1, resp)
+			messageSent.Event(ctx, 1, cfg.messagePolicy.attributes(info.FullMethod, resp)...)
+			rec.response(ctx, resp)
 		}
+		rec.end(ctx, err)
 
 		return resp, err
 	}
@@ -324,7 +375,10 @@ func UnaryServerInterceptor(tracer trace.Tracer, opts ...Option) grpc.UnaryServe
 // SendMsg method call.
 type serverStream struct {
 	grpc.ServerStream
-	ctx context.Context
+	ctx    context.Context
+	rec    *rpcRecorder
+	method string
+	policy *MessagePolicy
 
 	receivedMessageID int
 	sentMessageID     int
@@ -339,7 +393,8 @@ func (w *serverStream) RecvMsg(m interface{}) error {
 
 	if err == nil {
 		w.receivedMessageID++
-		messageReceived.Event(w.Context(), w.receivedMessageID, m)
+		messageReceived.Event(w.Context(), w.receivedMessageID, w.policy.attributes(w.method, m)...)
+		w.rec.request(w.Context(), m)
 	}
 
 	return err
@@ -349,30 +404,49 @@ func (w *serverStream) SendMsg(m interface{}) error {
 	err := w.ServerStream.SendMsg(m)
 
 	w.sentMessageID++
-	messageSent.Event(w.Context(), w.sentMessageID, m)
+	messageSent.Event(w.Context(), w.sentMessageID, w.policy.attributes(w.method, m)...)
+
+	if err == nil {
+		w.rec.response(w.Context(), m)
+	}
 
 	return err
 }
 
-func wrapServerStream(ctx context.Context, ss grpc.ServerStream) *serverStream {
+func wrapServerStream(ctx context.Context, ss grpc.ServerStream, method string, cfg *config, rec *rpcRecorder) *serverStream {
 	return &serverStream{
 		ServerStream: ss,
 		ctx:          ctx,
+		rec:          rec,
+		method:       method,
+		policy:       cfg.messagePolicy,
 	}
 }
 
 // StreamServerInterceptor returns a grpc.StreamServerInterceptor suitable
 // for use in a grpc.NewServer call.
-func StreamServerInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamServerInterceptor {
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	cfg := newInterceptorConfig(opts)
+	metrics := newServerMetrics(cfg.meter)
+
 	return func(
 		srv interface{},
 		ss grpc.ServerStream,
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
+		i := &InterceptorInfo{Method: info.FullMethod, StreamServerInfo: info, Type: StreamServer}
+		if !cfg.shouldTrace(i) {
+			return handler(srv, ss)
+		}
+
 		ctx := ss.Context()
 
 		requestMetadata, _ := metadata.FromIncomingContext(ctx)
+		samplingOpts, ok := cfg.samplingOptions(i, requestMetadata)
+		if !ok {
+			return handler(srv, ss)
+		}
 		metadataCopy := requestMetadata.Copy()
 
 		entries, spanCtx := Extract(ctx, &metadataCopy, opts...)
@@ -381,20 +455,22 @@ func StreamServerInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamSer
 		}))
 
 		name, attr := spanInfo(info.FullMethod, peerFromCtx(ctx))
-		ctx, span := tracer.Start(
+		ctx, span := cfg.tracer.Start(
 			trace.ContextWithRemoteSpanContext(ctx, spanCtx),
-			name,
-			trace.WithSpanKind(trace.SpanKindServer),
-			trace.WithAttributes(attr...),
+			cfg.spanName(i, name),
+			cfg.startOptions(trace.SpanKindServer, attr, samplingOpts...)...,
 		)
 		defer span.End()
 
-		err := handler(srv, wrapServerStream(ctx, ss))
+		rec := metrics.start(info.FullMethod)
+
+		err := handler(srv, wrapServerStream(ctx, ss, info.FullMethod, cfg, rec))
 
 		if err != nil {
 			s, _ := status.FromError(err)
 			span.SetStatus(s.Code(), s.Message())
 		}
+		rec.end(ctx, err)
 
 		return err
 	}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/api/trace/testtrace"
 
 	"github.com/stretchr/testify/assert"
@@ -89,7 +90,7 @@ func TestUnaryClientInterceptor(t *testing.T) {
 	sr := NewSpanRecorder()
 	tp := testtrace.NewProvider(testtrace.WithSpanRecorder(sr))
 	tracer := tp.Tracer("grpc/client")
-	unaryInterceptor := UnaryClientInterceptor(tracer)
+	unaryInterceptor := UnaryClientInterceptor(WithTracer(tracer))
 
 	req := &mockProtoMessage{}
 	reply := &mockProtoMessage{}
@@ -259,7 +260,7 @@ func TestStreamClientInterceptor(t *testing.T) {
 	sr := NewSpanRecorder()
 	tp := testtrace.NewProvider(testtrace.WithSpanRecorder(sr))
 	tracer := tp.Tracer("grpc/Server")
-	streamCI := StreamClientInterceptor(tracer)
+	streamCI := StreamClientInterceptor(WithTracer(tracer))
 
 	var mockClStr mockClientStream
 	method := "/github.com.serviceName/bar"
@@ -343,7 +344,7 @@ func TestServerInterceptorError(t *testing.T) {
 	sr := NewSpanRecorder()
 	tp := testtrace.NewProvider(testtrace.WithSpanRecorder(sr))
 	tracer := tp.Tracer("grpc/Server")
-	usi := UnaryServerInterceptor(tracer)
+	usi := UnaryServerInterceptor(WithTracer(tracer))
 	deniedErr := status.Error(codes.PermissionDenied, "PERMISSION_DENIED_TEXT")
 	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
 		return nil, deniedErr
@@ -426,3 +427,87 @@ func TestParseFullMethod(t *testing.T) {
 		assert.Equal(t, test.attr, a)
 	}
 }
+
+func TestUnaryServerInterceptorFilter(t *testing.T) {
+	sr := NewSpanRecorder()
+	tp := testtrace.NewProvider(testtrace.WithSpanRecorder(sr))
+	usi := UnaryServerInterceptor(
+		WithTracerProvider(tp),
+		WithFilter(func(info *InterceptorInfo) bool {
+			return info.UnaryServerInfo.FullMethod != "/grpc.health.v1.Health/Check"
+		}),
+	)
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return &mockProtoMessage{}, nil
+	}
+
+	_, err := usi(context.Background(), &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	require.NoError(t, err)
+	_, ok := sr.Get("grpc.health.v1.Health/Check")
+	assert.False(t, ok, "filtered RPC must not be traced")
+
+	_, err = usi(context.Background(), &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: "/serviceName/bar"}, handler)
+	require.NoError(t, err)
+	_, ok = sr.Get("serviceName/bar")
+	assert.True(t, ok, "unfiltered RPC must be traced")
+}
+
+func TestUnaryClientInterceptorSpanNameFormatter(t *testing.T) {
+	clientConn, err := grpc.Dial("fake:connection", grpc.WithInsecure())
+	require.NoError(t, err)
+
+	sr := NewSpanRecorder()
+	tp := testtrace.NewProvider(testtrace.WithSpanRecorder(sr))
+	unaryInterceptor := UnaryClientInterceptor(
+		WithTracerProvider(tp),
+		WithSpanNameFormatter(func(info *InterceptorInfo, name string) string {
+			assert.Equal(t, UnaryClient, info.Type)
+			return "client " + name
+		}),
+		WithSpanOptions(trace.WithAttributes(kv.String("custom", "value"))),
+	)
+
+	invoker := &mockUICInvoker{}
+	err = unaryInterceptor(context.Background(), "/serviceName/bar", &mockProtoMessage{}, &mockProtoMessage{}, clientConn, invoker.invoker)
+	require.NoError(t, err)
+
+	span, ok := sr.Get("client serviceName/bar")
+	require.True(t, ok, "missing span with formatted name")
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, kv.StringValue("value"), span.Attributes()["custom"])
+}
+
+func TestUnaryServerInterceptorSamplingHint(t *testing.T) {
+	sr := NewSpanRecorder()
+	tp := testtrace.NewProvider(testtrace.WithSpanRecorder(sr))
+	usi := UnaryServerInterceptor(
+		WithTracerProvider(tp),
+		WithSamplingHint(func(info *InterceptorInfo, md metadata.MD) (SamplingDecision, []kv.KeyValue) {
+			if info.Method == "/grpc.health.v1.Health/Check" {
+				return SamplingDrop, nil
+			}
+			var attrs []kv.KeyValue
+			if tenant := md.Get("tenant"); len(tenant) > 0 {
+				attrs = append(attrs, kv.String("tenant", tenant[0]))
+			}
+			return SamplingForce, attrs
+		}),
+	)
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return &mockProtoMessage{}, nil
+	}
+
+	_, err := usi(context.Background(), &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	require.NoError(t, err)
+	_, ok := sr.Get("grpc.health.v1.Health/Check")
+	assert.False(t, ok, "dropped RPC must not be traced")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("tenant", "acme"))
+	_, err = usi(ctx, &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: "/serviceName/bar"}, handler)
+	require.NoError(t, err)
+	span, ok := sr.Get("serviceName/bar")
+	require.True(t, ok, "forced RPC must be traced")
+	attrs := span.Attributes()
+	assert.Equal(t, kv.StringValue("acme"), attrs["tenant"])
+	assert.Equal(t, kv.IntValue(1), attrs[SamplingPriorityKey])
+}
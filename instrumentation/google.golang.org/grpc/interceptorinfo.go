@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"google.golang.org/grpc"
+)
+
+// InterceptorType is the kind of interceptor handling an RPC.
+type InterceptorType uint8
+
+// Interceptor kinds.
+const (
+	UndefinedInterceptor InterceptorType = iota
+	UnaryClient
+	StreamClient
+	UnaryServer
+	StreamServer
+)
+
+// InterceptorInfo is the information about an RPC that is available to
+// filters and span name formatters. UnaryServerInfo and StreamServerInfo are
+// only set by the matching server interceptor.
+type InterceptorInfo struct {
+	Method           string
+	UnaryServerInfo  *grpc.UnaryServerInfo
+	StreamServerInfo *grpc.StreamServerInfo
+	Type             InterceptorType
+}
+
+// Filter is a predicate used to determine whether an RPC should be traced.
+// A Filter must return true if the RPC should be traced.
+type Filter func(*InterceptorInfo) bool
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/standard"
+)
+
+// GRPCStatusCodeKey is the label key of the numeric gRPC status code an RPC
+// finished with.
+const GRPCStatusCodeKey = kv.Key("rpc.grpc.status_code")
+
+// Client RPC metrics
+const (
+	ClientDuration        = "rpc.client.duration"          // Outgoing RPC duration, microseconds
+	ClientCalls           = "rpc.client.calls"             // Outgoing RPCs completed, by status code
+	ClientRequestSize     = "rpc.client.request_size"      // Outgoing request message size, bytes
+	ClientResponseSize    = "rpc.client.response_size"     // Incoming response message size, bytes
+	ClientRequestsPerRPC  = "rpc.client.requests_per_rpc"  // Request messages sent per RPC
+	ClientResponsesPerRPC = "rpc.client.responses_per_rpc" // Response messages received per RPC
+)
+
+// Server RPC metrics
+const (
+	ServerDuration        = "rpc.server.duration"          // Incoming RPC duration, microseconds
+	ServerCalls           = "rpc.server.calls"             // Incoming RPCs completed, by status code
+	ServerRequestSize     = "rpc.server.request_size"      // Incoming request message size, bytes
+	ServerResponseSize    = "rpc.server.response_size"     // Outgoing response message size, bytes
+	ServerRequestsPerRPC  = "rpc.server.requests_per_rpc"  // Request messages received per RPC
+	ServerResponsesPerRPC = "rpc.server.responses_per_rpc" // Response messages sent per RPC
+)
+
+// rpcMetrics holds the instruments of one side (client or server) of an RPC.
+// A nil *rpcMetrics records nothing.
+type rpcMetrics struct {
+	duration        metric.Int64ValueRecorder
+	calls           metric.Int64Counter
+	requestSize     metric.Int64ValueRecorder
+	responseSize    metric.Int64ValueRecorder
+	requestsPerRPC  metric.Int64ValueRecorder
+	responsesPerRPC metric.Int64ValueRecorder
+}
+
+func handleErr(err error) {
+	if err != nil {
+		global.Handle(err)
+	}
+}
+
+func newClientMetrics(meter metric.Meter) *rpcMetrics {
+	return newRPCMetrics(meter,
+		ClientDuration, ClientCalls,
+		ClientRequestSize, ClientResponseSize,
+		ClientRequestsPerRPC, ClientResponsesPerRPC,
+	)
+}
+
+func newServerMetrics(meter metric.Meter) *rpcMetrics {
+	return newRPCMetrics(meter,
+		ServerDuration, ServerCalls,
+		ServerRequestSize, ServerResponseSize,
+		ServerRequestsPerRPC, ServerResponsesPerRPC,
+	)
+}
+
+func newRPCMetrics(meter metric.Meter, duration, calls, requestSize, responseSize, requestsPerRPC, responsesPerRPC string) *rpcMetrics {
+	if meter.MeterImpl() == nil {
+		return nil
+	}
+
+	m := &rpcMetrics{}
+	var err error
+
+	m.duration, err = meter.NewInt64ValueRecorder(duration)
+	handleErr(err)
+
+	m.calls, err = meter.NewInt64Counter(calls)
+	handleErr(err)
+
+	m.requestSize, err = meter.NewInt64ValueRecorder(requestSize)
+	handleErr(err)
+
+	m.responseSize, err = meter.NewInt64ValueRecorder(responseSize)
+	handleErr(err)
+
+	m.requestsPerRPC, err = meter.NewInt64ValueRecorder(requestsPerRPC)
+	handleErr(err)
+
+	m.responsesPerRPC, err = meter.NewInt64ValueRecorder(responsesPerRPC)
+	handleErr(err)
+
+	return m
+}
+
+// metricLabels returns the low-cardinality subset of the span attributes
+// produced by spanInfo for a gRPC FullMethod. Peer attributes are left out.
+func metricLabels(fullMethod string) []kv.KeyValue {
+	_, mAttrs := parseFullMethod(fullMethod)
+	return append([]kv.KeyValue{standard.RPCSystemGRPC}, mAttrs...)
+}
+
+// rpcRecorder records the metrics of a single RPC. Requests and responses may
+// be counted from different goroutines on streams, hence the atomic counters.
+// A nil *rpcRecorder records nothing.
+type rpcRecorder struct {
+	// requests and responses need to be aligned for 64-bit atomic operations.
+	requests  int64
+	responses int64
+
+	metrics *rpcMetrics
+	labels  []kv.KeyValue
+	start   time.Time
+}
+
+// start returns a recorder for a new RPC of fullMethod.
+func (m *rpcMetrics) start(fullMethod string) *rpcRecorder {
+	if m == nil {
+		return nil
+	}
+	return &rpcRecorder{
+		metrics: m,
+		labels:  metricLabels(fullMethod),
+		start:   time.Now(),
+	}
+}
+
+// request records a request message of the RPC.
+func (r *rpcRecorder) request(ctx context.Context, message interface{}) {
+	if r == nil {
+		return
+	}
+	atomic.AddInt64(&r.requests, 1)
+	if p, ok := message.(proto.Message); ok {
+		r.metrics.requestSize.Record(ctx, int64(proto.Size(p)), r.labels...)
+	}
+}
+
+// response records a response message of the RPC.
+func (r *rpcRecorder) response(ctx context.Context, message interface{}) {
+	if r == nil {
+		return
+	}
+	atomic.AddInt64(&r.responses, 1)
+	if p, ok := message.(proto.Message); ok {
+		r.metrics.responseSize.Record(ctx, int64(proto.Size(p)), r.labels...)
+	}
+}
+
+// end records the completion of the RPC with the status derived from err.
+func (r *rpcRecorder) end(ctx context.Context, err error) {
+	if r == nil {
+		return
+	}
+
+	code := codes.OK
+	if err != nil {
+		s, _ := status.FromError(err)
+		code = s.Code()
+	}
+	labels := append(r.labels[:len(r.labels):len(r.labels)], GRPCStatusCodeKey.Uint32(uint32(code)))
+
+	r.metrics.duration.Record(ctx, time.Since(r.start).Microseconds(), labels...)
+	r.metrics.calls.Add(ctx, 1, labels...)
+	r.metrics.requestsPerRPC.Record(ctx, atomic.LoadInt64(&r.requests), labels...)
+	r.metrics.responsesPerRPC.Record(ctx, atomic.LoadInt64(&r.responses), labels...)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
+
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
+)
+
+type measurement struct {
+	labels []kv.KeyValue
+	value  int64
+}
+
+func measurementsByName(impl *mockmeter.MeterImpl) map[string][]measurement {
+	got := map[string][]measurement{}
+	for _, batch := range impl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			got[name] = append(got[name], measurement{labels: batch.Labels, value: m.Number.AsInt64()})
+		}
+	}
+	return got
+}
+
+func TestUnaryClientInterceptorMetrics(t *testing.T) {
+	clientConn, err := grpc.Dial("fake:connection", grpc.WithInsecure())
+	require.NoError(t, err)
+
+	impl, meter := mockmeter.NewMeter()
+	tracer := testtrace.NewProvider().Tracer("grpc/client")
+	unaryInterceptor := UnaryClientInterceptor(WithTracer(tracer), WithMeter(meter))
+
+	invoker := &mockUICInvoker{}
+	err = unaryInterceptor(context.Background(), "/serviceName/bar", &mockProtoMessage{}, &mockProtoMessage{}, clientConn, invoker.invoker)
+	require.NoError(t, err)
+
+	got := measurementsByName(impl)
+	expectedLabels := []kv.KeyValue{
+		standard.RPCSystemGRPC,
+		standard.RPCServiceKey.String("serviceName"),
+		standard.RPCMethodKey.String("bar"),
+		GRPCStatusCodeKey.Uint32(uint32(codes.OK)),
+	}
+
+	for _, name := range []string{ClientDuration, ClientCalls, ClientRequestsPerRPC, ClientResponsesPerRPC} {
+		require.Len(t, got[name], 1, name)
+		assert.ElementsMatch(t, expectedLabels, got[name][0].labels, name)
+	}
+	assert.Equal(t, int64(1), got[ClientCalls][0].value)
+	assert.Equal(t, int64(1), got[ClientRequestsPerRPC][0].value)
+	assert.Equal(t, int64(1), got[ClientResponsesPerRPC][0].value)
+	assert.Len(t, got[ClientRequestSize], 1)
+	assert.Len(t, got[ClientResponseSize], 1)
+}
+
+func TestUnaryServerInterceptorMetricsError(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	tracer := testtrace.NewProvider().Tracer("grpc/server")
+	usi := UnaryServerInterceptor(WithTracer(tracer), WithMeter(meter))
+
+	deniedErr := status.Error(codes.PermissionDenied, "PERMISSION_DENIED_TEXT")
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return nil, deniedErr
+	}
+	_, err := usi(context.Background(), &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: "/serviceName/bar"}, handler)
+	require.Error(t, err)
+
+	got := measurementsByName(impl)
+	require.Len(t, got[ServerCalls], 1)
+	assert.Contains(t, got[ServerCalls][0].labels, GRPCStatusCodeKey.Uint32(uint32(codes.PermissionDenied)))
+	assert.Equal(t, int64(1), got[ServerRequestsPerRPC][0].value)
+	assert.Equal(t, int64(0), got[ServerResponsesPerRPC][0].value)
+	assert.Empty(t, got[ServerResponseSize])
+}
+
+func TestInterceptorWithoutMeter(t *testing.T) {
+	assert.Nil(t, newServerMetrics(newConfig(nil).meter))
+}
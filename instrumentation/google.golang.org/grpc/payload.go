@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/jsonpb" //nolint:staticcheck
+	"github.com/golang/protobuf/proto"  //nolint:staticcheck
+
+	"google.golang.org/grpc/encoding"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+)
+
+// Attribute keys that can be added to message events by a MessagePolicy.
+const (
+	MessagePayloadKey          = kv.Key("message.payload")           // JSON rendering of the message, after redaction
+	MessagePayloadTruncatedKey = kv.Key("message.payload_truncated") // set if the rendering exceeded the size cap
+)
+
+// DefaultMaxPayloadSize is the cap, in bytes, of a captured message payload
+// when MessagePolicy.MaxPayloadSize is not set.
+const DefaultMaxPayloadSize = 1024
+
+// MessagePolicy configures the optional information the interceptors attach
+// to each message event, in addition to the message type and id. The zero
+// value only records the uncompressed size of proto messages.
+type MessagePolicy struct {
+	// Compressor is the name of a compressor registered with
+	// google.golang.org/grpc/encoding, such as "gzip". When set, every proto
+	// message is marshaled and compressed with it to record its compressed
+	// size. This is costly and meant for debugging.
+	Compressor string
+
+	// Capture reports whether the payloads of the RPC with the given full
+	// method are rendered as JSON on message events. When nil, no payload is
+	// captured.
+	Capture func(fullMethod string) bool
+
+	// MaxPayloadSize caps the size in bytes of a captured payload, which is
+	// truncated beyond it. DefaultMaxPayloadSize is used when it is zero.
+	MaxPayloadSize int
+
+	// Redact is called for every field of a captured payload with its
+	// dotted JSON path, such as "user.password", and its decoded JSON
+	// value. The returned value replaces the original one. When nil, the
+	// payload is recorded as is.
+	Redact func(fullMethod, path string, value interface{}) interface{}
+}
+
+// WithMessagePolicy configures the information attached to the message events
+// of the interceptors.
+func WithMessagePolicy(p MessagePolicy) Option {
+	return func(c *config) {
+		c.messagePolicy = &p
+	}
+}
+
+// attributes returns the message event attributes of message, which is
+// exchanged by the RPC of fullMethod. A nil policy only records the
+// uncompressed size.
+func (p *MessagePolicy) attributes(fullMethod string, message interface{}) []kv.KeyValue {
+	msg, ok := message.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	attrs := []kv.KeyValue{standard.RPCMessageUncompressedSizeKey.Int(proto.Size(msg))}
+	if p == nil {
+		return attrs
+	}
+
+	if p.Compressor != "" {
+		if size, ok := compressedSize(p.Compressor, msg); ok {
+			attrs = append(attrs, standard.RPCMessageCompressedSizeKey.Int(size))
+		}
+	}
+
+	if p.Capture != nil && p.Capture(fullMethod) {
+		attrs = append(attrs, p.payload(fullMethod, msg)...)
+	}
+
+	return attrs
+}
+
+// compressedSize returns the size of msg once marshaled and compressed with the
+// named compressor. It returns false if the compressor is not registered or
+// the message cannot be compressed.
+func compressedSize(name string, msg proto.Message) (int, bool) {
+	c := encoding.GetCompressor(name)
+	if c == nil {
+		return 0, false
+	}
+
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return 0, false
+	}
+
+	w := &countingWriter{}
+	wc, err := c.Compress(w)
+	if err != nil {
+		return 0, false
+	}
+	if _, err := wc.Write(b); err != nil {
+		return 0, false
+	}
+	if err := wc.Close(); err != nil {
+		return 0, false
+	}
+	return w.n, true
+}
+
+// countingWriter counts the bytes written to it and discards them.
+type countingWriter struct {
+	n int
+}
+
+var _ io.Writer = &countingWriter{}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	w.n += len(b)
+	return len(b), nil
+}
+
+// payload returns the redacted, size-capped JSON rendering of msg.
+func (p *MessagePolicy) payload(fullMethod string, msg proto.Message) []kv.KeyValue {
+	m := jsonpb.Marshaler{OrigName: true}
+	rendered, err := m.MarshalToString(msg)
+	if err != nil {
+		return nil
+	}
+
+	if p.Redact != nil {
+		var v interface{}
+		if err := json.Unmarshal([]byte(rendered), &v); err != nil {
+			return nil
+		}
+		b, err := json.Marshal(redact(v, "", func(path string, value interface{}) interface{} {
+			return p.Redact(fullMethod, path, value)
+		}))
+		if err != nil {
+			return nil
+		}
+		rendered = string(b)
+	}
+
+	max := p.MaxPayloadSize
+	if max <= 0 {
+		max = DefaultMaxPayloadSize
+	}
+	if len(rendered) > max {
+		// Do not cut a multi-byte character in half.
+		for max > 0 && !utf8.RuneStart(rendered[max]) {
+			max--
+		}
+		return []kv.KeyValue{
+			MessagePayloadKey.String(rendered[:max]),
+			MessagePayloadTruncatedKey.Bool(true),
+		}
+	}
+	return []kv.KeyValue{MessagePayloadKey.String(rendered)}
+}
+
+// redact walks the decoded JSON value v depth first and replaces every object
+// field and array element with the value returned by f for its path.
+func redact(v interface{}, path string, f func(path string, value interface{}) interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			t[k] = f(p, redact(e, p, f))
+		}
+	case []interface{}:
+		for i, e := range t {
+			p := path + "." + strconv.Itoa(i)
+			t[i] = f(p, redact(e, p, f))
+		}
+	}
+	return v
+}
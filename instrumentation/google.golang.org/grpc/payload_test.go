@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip" // register the gzip compressor
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
+)
+
+func TestMessagePolicyAttributes(t *testing.T) {
+	msg := status.New(codes.NotFound, "secret").Proto()
+
+	var nilPolicy *MessagePolicy
+	assert.Equal(t, []kv.KeyValue{
+		standard.RPCMessageUncompressedSizeKey.Int(10),
+	}, nilPolicy.attributes("/serviceName/bar", msg))
+	assert.Empty(t, nilPolicy.attributes("/serviceName/bar", "not a proto message"))
+
+	p := &MessagePolicy{
+		Compressor: "gzip",
+		Capture: func(fullMethod string) bool {
+			return fullMethod == "/serviceName/bar"
+		},
+		Redact: func(fullMethod, path string, value interface{}) interface{} {
+			if path == "message" {
+				return "REDACTED"
+			}
+			return value
+		},
+	}
+
+	attrs := p.attributes("/serviceName/bar", msg)
+	got := map[kv.Key]kv.Value{}
+	for _, a := range attrs {
+		got[a.Key] = a.Value
+	}
+	assert.Equal(t, kv.IntValue(10), got[standard.RPCMessageUncompressedSizeKey])
+	assert.Contains(t, got, standard.RPCMessageCompressedSizeKey)
+	assert.Equal(t, kv.StringValue(`{"code":5,"message":"REDACTED"}`), got[MessagePayloadKey])
+	assert.NotContains(t, got, MessagePayloadTruncatedKey)
+
+	assert.Len(t, p.attributes("/serviceName/other", msg), 2, "payload must only be captured for selected methods")
+}
+
+func TestMessagePolicyTruncation(t *testing.T) {
+	p := &MessagePolicy{
+		Capture:        func(string) bool { return true },
+		MaxPayloadSize: 8,
+	}
+
+	attrs := p.payload("/serviceName/bar", status.New(codes.NotFound, "not found").Proto())
+	assert.Equal(t, []kv.KeyValue{
+		MessagePayloadKey.String(`{"code":`),
+		MessagePayloadTruncatedKey.Bool(true),
+	}, attrs)
+
+	// The limit falls in the middle of the two bytes of "é".
+	p.MaxPayloadSize = len(`{"code":5,"message":"`) + 1
+	attrs = p.payload("/serviceName/bar", status.New(codes.NotFound, "é").Proto())
+	assert.Equal(t, []kv.KeyValue{
+		MessagePayloadKey.String(`{"code":5,"message":"`),
+		MessagePayloadTruncatedKey.Bool(true),
+	}, attrs)
+}
+
+func TestUnaryServerInterceptorMessagePolicy(t *testing.T) {
+	sr := NewSpanRecorder()
+	tp := testtrace.NewProvider(testtrace.WithSpanRecorder(sr))
+	usi := UnaryServerInterceptor(
+		WithTracerProvider(tp),
+		WithMessagePolicy(MessagePolicy{Capture: func(string) bool { return true }}),
+	)
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	_, err := usi(context.Background(), &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: "/serviceName/bar"}, handler)
+	require.Error(t, err)
+
+	span, ok := sr.Get("serviceName/bar")
+	require.True(t, ok)
+	require.Len(t, span.Events(), 2)
+	assert.Equal(t, kv.StringValue(`{"code":5,"message":"missing"}`), span.Events()[1].Attributes[MessagePayloadKey])
+}
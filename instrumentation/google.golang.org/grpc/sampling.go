@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+
+	"go.opentelemetry.io/contrib/internal/sampling"
+)
+
+// SamplingPriorityKey marks the spans of RPCs forced by SamplingForce, with
+// a value of 1. It is the same key as the one of the net/http
+// instrumentation. The SDK samplers ignore it: the PrioritySampler of the
+// go.opentelemetry.io/contrib/sdk/sampling package samples on it.
+const SamplingPriorityKey = sampling.PriorityKey
+
+// SamplingDecision is the outcome of a SamplingHint for an RPC.
+type SamplingDecision int
+
+const (
+	// SamplingDefault lets the sampler decide.
+	SamplingDefault = SamplingDecision(sampling.Default)
+	// SamplingDrop neither traces nor measures the RPC, as if a Filter
+	// rejected it.
+	SamplingDrop = SamplingDecision(sampling.Drop)
+	// SamplingRecord starts a recording span whatever the sampler decides.
+	SamplingRecord = SamplingDecision(sampling.Record)
+	// SamplingForce starts a recording span carrying SamplingPriorityKey,
+	// which is exported only with a sampler that samples on that key, such
+	// as PrioritySampler.
+	SamplingForce = SamplingDecision(sampling.Force)
+)
+
+// SamplingHint is called for every RPC accepted by the filters, before its
+// span starts, with the RPC information and its metadata: outgoing metadata
+// on clients and incoming metadata on servers. It returns the sampling
+// decision for the RPC and attributes that are set on the span at start, and
+// are thus visible to the sampler.
+type SamplingHint func(info *InterceptorInfo, md metadata.MD) (SamplingDecision, []kv.KeyValue)
+
+// WithSamplingHint configures the interceptors to call h for every RPC to
+// adjust its sampling.
+func WithSamplingHint(h SamplingHint) Option {
+	return func(c *config) {
+		c.samplingHint = h
+	}
+}
+
+// samplingOptions returns the span start options corresponding to the
+// sampling hint for info, and false if the RPC must not be traced.
+func (c *config) samplingOptions(info *InterceptorInfo, md metadata.MD) ([]trace.StartOption, bool) {
+	if c.samplingHint == nil {
+		return nil, true
+	}
+	decision, attrs := c.samplingHint(info, md)
+	return sampling.StartOptions(sampling.Decision(decision), attrs)
+}
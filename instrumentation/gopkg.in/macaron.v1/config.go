@@ -23,6 +23,9 @@ import (
 type Config struct {
 	Tracer      trace.Tracer
 	Propagators propagation.Propagators
+
+	RecoverPanics bool
+	Repanic       bool
 }
 
 // Option specifies instrumentation configuration options.
@@ -46,3 +49,14 @@ func WithPropagators(propagators propagation.Propagators) Option {
 		cfg.Propagators = propagators
 	}
 }
+
+// WithPanicRecovery enables recovery from panics in the handlers following
+// the middleware, recording them on the span with an error status. The
+// panic is raised again if repanic is true, for macaron.Recovery to handle,
+// otherwise status 500 is written unless the response was already written.
+func WithPanicRecovery(repanic bool) Option {
+	return func(cfg *Config) {
+		cfg.RecoverPanics = true
+		cfg.Repanic = repanic
+	}
+}
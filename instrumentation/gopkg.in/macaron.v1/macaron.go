@@ -17,9 +17,12 @@ package macaron
 import (
 	"fmt"
 	"net/http"
+	"runtime/debug"
 
 	"gopkg.in/macaron.v1"
 
+	"go.opentelemetry.io/contrib/internal/panics"
+
 	otelglobal "go.opentelemetry.io/otel/api/global"
 	otelpropagation "go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/standard"
@@ -63,6 +66,20 @@ func Middleware(service string, opts ...Option) macaron.Handler {
 		ctx, span := cfg.Tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
+		if cfg.RecoverPanics {
+			defer func() {
+				if p := recover(); p != nil {
+					if !cfg.Repanic && !c.Resp.Written() {
+						c.Resp.WriteHeader(http.StatusInternalServerError)
+					}
+					panics.RecordHTTP(ctx, span, p, debug.Stack())
+					if cfg.Repanic {
+						panic(p)
+					}
+				}
+			}()
+		}
+
 		// pass the span through the request context
 		c.Req.Request = c.Req.Request.WithContext(ctx)
 
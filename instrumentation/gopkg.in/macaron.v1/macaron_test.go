@@ -186,3 +186,45 @@ func TestPropagationWithCustomPropagators(t *testing.T) {
 
 	m.ServeHTTP(w, r)
 }
+
+func TestPanicRecovery(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	m := macaron.New()
+	m.Use(Middleware("foobar", WithTracer(tracer), WithPanicRecovery(false)))
+	m.Get("/panic", func(ctx *macaron.Context) {
+		panic("oh no")
+	})
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, kv.IntValue(http.StatusInternalServerError), span.Attributes["http.status_code"])
+	assert.Equal(t, "oh no", span.StatusMessage)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+	assert.Equal(t, kv.StringValue("oh no"), span.Events[0].Attributes["exception.message"])
+}
+
+func TestPanicRecoveryRepanic(t *testing.T) {
+	tracer := mocktrace.NewTracer("test-tracer")
+
+	m := macaron.New()
+	m.Use(Middleware("foobar", WithTracer(tracer), WithPanicRecovery(true)))
+	m.Get("/panic", func(ctx *macaron.Context) {
+		panic("oh no")
+	})
+	assert.PanicsWithValue(t, "oh no", func() {
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", nil))
+	})
+
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "oh no", span.StatusMessage)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+}
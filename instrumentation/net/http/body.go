@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// Attribute keys of the body events recorded by the Handler when
+// WithBodyCapture is used.
+const (
+	BodyKey            = kv.Key("http.body")              // the first bytes of the body, after redaction
+	BodyContentTypeKey = kv.Key("http.body.content_type") // the media type of the body
+	BodyTruncatedKey   = kv.Key("http.body.truncated")    // set if the body was larger than the captured size
+)
+
+// DefaultMaxBodySize is the number of bytes of each body that are captured
+// when BodyCapture.MaxSize is not set.
+const DefaultMaxBodySize = 1024
+
+// DefaultBodyContentTypes are the media types of the bodies that are captured
+// when BodyCapture.ContentTypes is empty.
+var DefaultBodyContentTypes = []string{
+	"application/json",
+	"application/xml",
+	"application/x-www-form-urlencoded",
+	"text/*",
+}
+
+// BodyCapture configures the Handler to record the first bytes of request and
+// response bodies as span events. Bodies are only recorded for responses with
+// an error status code (4xx or 5xx), or for requests accepted by Filter.
+type BodyCapture struct {
+	// MaxSize is the number of bytes of each body that are captured.
+	// DefaultMaxBodySize is used when it is zero.
+	MaxSize int
+
+	// ContentTypes is the allowlist of media types whose bodies are
+	// captured. A "type/*" entry matches all subtypes. When empty,
+	// DefaultBodyContentTypes is used.
+	ContentTypes []string
+
+	// Filter selects requests whose bodies are recorded regardless of the
+	// response status code. When nil, only error responses are recorded.
+	Filter Filter
+
+	// Redact is called with every captured body and its media type before
+	// it is recorded, and returns the bytes to record. When nil, bodies are
+	// recorded as is.
+	Redact func(body []byte, contentType string) []byte
+}
+
+// WithBodyCapture configures the Handler to record request and response
+// bodies as described by bc.
+func WithBodyCapture(bc BodyCapture) Option {
+	return OptionFunc(func(c *Config) {
+		c.BodyCapture = &bc
+	})
+}
+
+func (bc *BodyCapture) maxSize() int {
+	if bc.MaxSize <= 0 {
+		return DefaultMaxBodySize
+	}
+	return bc.MaxSize
+}
+
+// allows returns the media type of the contentType header value and whether
+// bodies of that type are captured.
+func (bc *BodyCapture) allows(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	allowed := bc.ContentTypes
+	if len(allowed) == 0 {
+		allowed = DefaultBodyContentTypes
+	}
+	for _, a := range allowed {
+		if a == mediaType {
+			return mediaType, true
+		}
+		if strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, a[:len(a)-1]) {
+			return mediaType, true
+		}
+	}
+	return "", false
+}
+
+// shouldRecord reports whether the captured bodies of r, answered with
+// statusCode, are recorded.
+func (bc *BodyCapture) shouldRecord(r *http.Request, statusCode int) bool {
+	if statusCode >= http.StatusBadRequest {
+		return true
+	}
+	return bc.Filter != nil && bc.Filter(r)
+}
+
+// record adds an event with the captured body buf to span, if its content
+// type is allowed.
+func (bc *BodyCapture) record(ctx context.Context, span trace.Span, name string, buf *bodyBuffer, contentType string) {
+	mediaType, ok := bc.allows(contentType)
+	if !ok || buf == nil || len(buf.buf) == 0 {
+		return
+	}
+
+	body := buf.buf
+	if bc.Redact != nil {
+		body = bc.Redact(body, mediaType)
+	}
+
+	attrs := []kv.KeyValue{
+		BodyKey.String(string(body)),
+		BodyContentTypeKey.String(mediaType),
+	}
+	if buf.truncated {
+		attrs = append(attrs, BodyTruncatedKey.Bool(true))
+	}
+	span.AddEvent(ctx, name, attrs...)
+}
+
+// bodyBuffer keeps the first max bytes written to it. A nil *bodyBuffer
+// discards everything.
+type bodyBuffer struct {
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+func newBodyBuffer(max int) *bodyBuffer {
+	return &bodyBuffer{max: max}
+}
+
+func (b *bodyBuffer) write(p []byte) {
+	if b == nil || len(p) == 0 {
+		return
+	}
+	if room := b.max - len(b.buf); len(p) > room {
+		b.truncated = true
+		p = p[:room]
+	}
+	b.buf = append(b.buf, p...)
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mocktrace "go.opentelemetry.io/contrib/internal/trace"
+)
+
+func bodyCaptureHandler(t *testing.T, tracer *mocktrace.Tracer, status int, bc BodyCapture) http.Handler {
+	return NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := ioutil.ReadAll(r.Body); err != nil {
+				t.Fatal(err)
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(status)
+			if _, err := io.WriteString(w, `{"error":"invalid password"}`); err != nil {
+				t.Fatal(err)
+			}
+		}), "test_handler",
+		WithTracer(tracer),
+		WithBodyCapture(bc),
+	)
+}
+
+func newBodyRequest(t *testing.T, contentType, body string) *http.Request {
+	r, err := http.NewRequest(http.MethodPost, "http://localhost/login", strings.NewReader(body))
+	require.NoError(t, err)
+	r.Header.Set("Content-Type", contentType)
+	return r
+}
+
+func TestHandlerBodyCaptureOnError(t *testing.T) {
+	var span *mocktrace.Span
+	tracer := mocktrace.Tracer{
+		OnSpanStarted: func(s *mocktrace.Span) {
+			span = s
+		},
+	}
+
+	h := bodyCaptureHandler(t, &tracer, http.StatusBadRequest, BodyCapture{
+		MaxSize: 16,
+		Redact: func(body []byte, contentType string) []byte {
+			return bytes.ReplaceAll(body, []byte("password"), []byte("********"))
+		},
+	})
+	h.ServeHTTP(httptest.NewRecorder(), newBodyRequest(t, "text/plain", "user=bob"))
+
+	require.NotNil(t, span)
+	recorded := span.Events
+	require.Len(t, recorded, 2)
+
+	assert.Equal(t, "http.request.body", recorded[0].Name)
+	assert.Equal(t, "user=bob", recorded[0].Attributes[BodyKey].AsString())
+	assert.Equal(t, "text/plain", recorded[0].Attributes[BodyContentTypeKey].AsString())
+	assert.NotContains(t, recorded[0].Attributes, BodyTruncatedKey)
+
+	assert.Equal(t, "http.response.body", recorded[1].Name)
+	assert.Equal(t, `{"error":"invali`, recorded[1].Attributes[BodyKey].AsString())
+	assert.Equal(t, "application/json", recorded[1].Attributes[BodyContentTypeKey].AsString())
+	assert.True(t, recorded[1].Attributes[BodyTruncatedKey].AsBool())
+}
+
+func TestHandlerBodyCaptureSkipped(t *testing.T) {
+	var span *mocktrace.Span
+	tracer := mocktrace.Tracer{
+		OnSpanStarted: func(s *mocktrace.Span) {
+			span = s
+		},
+	}
+
+	// Successful responses are not recorded unless the filter matches.
+	h := bodyCaptureHandler(t, &tracer, http.StatusOK, BodyCapture{})
+	h.ServeHTTP(httptest.NewRecorder(), newBodyRequest(t, "text/plain", "user=bob"))
+	require.NotNil(t, span)
+	assert.Empty(t, span.Events)
+
+	// Bodies of types outside the allowlist are not recorded.
+	h = bodyCaptureHandler(t, &tracer, http.StatusOK, BodyCapture{
+		ContentTypes: []string{"application/*"},
+		Filter: func(r *http.Request) bool {
+			return r.URL.Path == "/login"
+		},
+	})
+	h.ServeHTTP(httptest.NewRecorder(), newBodyRequest(t, "text/plain", "user=bob"))
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "http.response.body", span.Events[0].Name)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/api/kv"
+)
+
+// Prefixes of the attribute keys of captured headers and query parameters.
+// The lowercased name, with dashes replaced by underscores, is appended.
+const (
+	RequestHeaderKeyPrefix  = "http.request.header."
+	ResponseHeaderKeyPrefix = "http.response.header."
+	QueryParameterKeyPrefix = "http.request.query."
+)
+
+// RedactedValue replaces the captured values of redacted headers.
+const RedactedValue = "REDACTED"
+
+// DefaultRedactedHeaders are the headers whose captured values are redacted
+// unless WithRedactedHeaders is used.
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// valueCapture records an allowlist of values of a http.Header or url.Values
+// as span attributes.
+type valueCapture struct {
+	names    []string
+	keys     []kv.Key
+	redacted []bool
+}
+
+// newHeaderCapture returns a capture of the headers, with the values of any
+// of the redacted headers replaced by RedactedValue.
+func newHeaderCapture(prefix string, headers, redacted []string) valueCapture {
+	redact := make(map[string]bool, len(redacted))
+	for _, h := range redacted {
+		redact[http.CanonicalHeaderKey(h)] = true
+	}
+
+	c := valueCapture{}
+	for _, h := range headers {
+		name := http.CanonicalHeaderKey(h)
+		c.names = append(c.names, name)
+		c.keys = append(c.keys, attributeKey(prefix, name))
+		c.redacted = append(c.redacted, redact[name])
+	}
+	return c
+}
+
+// newQueryCapture returns a capture of the query parameters.
+func newQueryCapture(params []string) valueCapture {
+	c := valueCapture{}
+	for _, p := range params {
+		c.names = append(c.names, p)
+		c.keys = append(c.keys, attributeKey(QueryParameterKeyPrefix, p))
+		c.redacted = append(c.redacted, false)
+	}
+	return c
+}
+
+func attributeKey(prefix, name string) kv.Key {
+	return kv.Key(prefix + strings.ReplaceAll(strings.ToLower(name), "-", "_"))
+}
+
+// headerAttributes returns the attributes of the captured headers present in h.
+func (c valueCapture) headerAttributes(h http.Header) []kv.KeyValue {
+	if len(c.names) == 0 {
+		return nil
+	}
+	return c.attributes(func(name string) []string {
+		return h[name]
+	})
+}
+
+// queryAttributes returns the attributes of the captured parameters present
+// in the query of u.
+func (c valueCapture) queryAttributes(u *url.URL) []kv.KeyValue {
+	if len(c.names) == 0 || u == nil || u.RawQuery == "" {
+		return nil
+	}
+	q := u.Query()
+	return c.attributes(func(name string) []string {
+		return q[name]
+	})
+}
+
+func (c valueCapture) attributes(values func(name string) []string) []kv.KeyValue {
+	var attrs []kv.KeyValue
+	for i, name := range c.names {
+		v := values(name)
+		if len(v) == 0 {
+			continue
+		}
+		if c.redacted[i] {
+			r := make([]string, len(v))
+			for j := range r {
+				r[j] = RedactedValue
+			}
+			v = r
+		}
+		attrs = append(attrs, c.keys[i].Array(v))
+	}
+	return attrs
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/kv"
+
+	mocktrace "go.opentelemetry.io/contrib/internal/trace"
+)
+
+func TestHandlerCapturedHeaders(t *testing.T) {
+	var span *mocktrace.Span
+	tracer := mocktrace.Tracer{
+		OnSpanStarted: func(s *mocktrace.Span) {
+			span = s
+		},
+	}
+
+	h := NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Add("Set-Cookie", "session=secret")
+			w.WriteHeader(http.StatusOK)
+		}), "test_handler",
+		WithTracer(&tracer),
+		WithCapturedRequestHeaders("x-request-id", "Authorization", "X-Missing"),
+		WithCapturedResponseHeaders("Content-Type", "Set-Cookie"),
+		WithCapturedQueryParameters("page"),
+	)
+
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/users?page=2&token=secret", nil)
+	require.NoError(t, err)
+	r.Header.Set("X-Request-Id", "42")
+	r.Header.Set("Authorization", "Bearer secret")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.NotNil(t, span)
+	assert.Equal(t, kv.ArrayValue([]string{"42"}), span.Attributes["http.request.header.x_request_id"])
+	assert.Equal(t, kv.ArrayValue([]string{RedactedValue}), span.Attributes["http.request.header.authorization"])
+	assert.NotContains(t, span.Attributes, kv.Key("http.request.header.x_missing"))
+	assert.Equal(t, kv.ArrayValue([]string{"2"}), span.Attributes["http.request.query.page"])
+	assert.NotContains(t, span.Attributes, kv.Key("http.request.query.token"))
+	assert.Equal(t, kv.ArrayValue([]string{"text/plain"}), span.Attributes["http.response.header.content_type"])
+	assert.Equal(t, kv.ArrayValue([]string{RedactedValue}), span.Attributes["http.response.header.set_cookie"])
+}
+
+func TestTransportCapturedHeaders(t *testing.T) {
+	var span *mocktrace.Span
+	tracer := mocktrace.Tracer{
+		OnSpanStarted: func(s *mocktrace.Span) {
+			span = s
+		},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Server", "test")
+	}))
+	defer ts.Close()
+
+	tr := NewTransport(
+		http.DefaultTransport,
+		WithTracer(&tracer),
+		WithCapturedRequestHeaders("Authorization"),
+		WithCapturedResponseHeaders("X-Server"),
+		WithRedactedHeaders(),
+	)
+
+	r, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	r.Header.Set("Authorization", "Basic dGVzdA==")
+
+	res, err := tr.RoundTrip(r)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	require.NotNil(t, span)
+	assert.Equal(t, kv.ArrayValue([]string{"Basic dGVzdA=="}), span.Attributes["http.request.header.authorization"])
+	assert.Equal(t, kv.ArrayValue([]string{"test"}), span.Attributes["http.response.header.x_server"])
+}
@@ -17,6 +17,8 @@ package http
 import (
 	"net/http"
 
+	"go.opentelemetry.io/contrib/internal/panics"
+
 	"go.opentelemetry.io/otel/api/kv"
 )
 
@@ -28,12 +30,28 @@ const (
 	WriteErrorKey = kv.Key("http.write_error") // if an error occurred while writing a reply, the string of the error (io.EOF is not recorded)
 )
 
+// Attribute keys of the exception event recorded when a panic is recovered.
+const (
+	ExceptionTypeKey       = panics.ExceptionTypeKey       // the Go type of the panic value
+	ExceptionMessageKey    = panics.ExceptionMessageKey    // the panic value, formatted with fmt.Sprint
+	ExceptionStacktraceKey = panics.ExceptionStacktraceKey // the stack trace of the panicking goroutine
+)
+
 // Server HTTP metrics
 const (
 	RequestCount          = "http.server.request_count"           // Incoming request count total
 	RequestContentLength  = "http.server.request_content_length"  // Incoming request bytes total
 	ResponseContentLength = "http.server.response_content_length" // Incoming response bytes total
 	ServerLatency         = "http.server.duration"                // Incoming end to end duration, microseconds
+	ServerPanics          = "http.server.panics"                  // Incoming requests whose handler panicked, labelled with the 500 status code they are recorded with
+)
+
+// Client HTTP metrics
+const (
+	ClientRequestContentLength  = "http.client.request_content_length"  // Outgoing request bytes total
+	ClientResponseContentLength = "http.client.response_content_length" // Outgoing response bytes total
+	ClientLatency               = "http.client.duration"                // Outgoing end to end duration, microseconds
+	ClientActiveRequests        = "http.client.active_requests"         // Outgoing requests in flight
 )
 
 // Filter is a predicate used to determine whether a given http.request should
@@ -17,6 +17,8 @@ package http
 import (
 	"net/http"
 
+	otelhttptrace "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace"
+
 	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/trace"
@@ -33,6 +35,21 @@ type Config struct {
 	WriteEvent        bool
 	Filters           []Filter
 	SpanNameFormatter func(string, *http.Request) string
+	RouteResolver     RouteResolver
+	SamplingHint      SamplingHint
+
+	CapturedRequestHeaders  []string
+	CapturedResponseHeaders []string
+	CapturedQueryParameters []string
+	RedactedHeaders         []string
+
+	BodyCapture *BodyCapture
+
+	RecoverPanics bool
+	Repanic       bool
+
+	ClientTrace        bool
+	ClientTraceOptions []otelhttptrace.Option
 }
 
 // Option Interface used for setting *optional* Config properties
@@ -148,3 +165,74 @@ func WithSpanNameFormatter(f func(operation string, r *http.Request) string) Opt
 		c.SpanNameFormatter = f
 	})
 }
+
+// WithRouteResolver configures the Handler to resolve the route template
+// matched by each request with rr. The template is used as the default span
+// name, as the http.route attribute and as a metric label, and is available
+// to the handler and span name formatters through RouteFromContext.
+func WithRouteResolver(rr RouteResolver) Option {
+	return OptionFunc(func(c *Config) {
+		c.RouteResolver = rr
+	})
+}
+
+// WithCapturedRequestHeaders adds headers to the allowlist of request headers
+// recorded as span attributes, keyed with RequestHeaderKeyPrefix followed by
+// the lowercased header name.
+func WithCapturedRequestHeaders(headers ...string) Option {
+	return OptionFunc(func(c *Config) {
+		c.CapturedRequestHeaders = append(c.CapturedRequestHeaders, headers...)
+	})
+}
+
+// WithCapturedResponseHeaders adds headers to the allowlist of response
+// headers recorded as span attributes, keyed with ResponseHeaderKeyPrefix
+// followed by the lowercased header name.
+func WithCapturedResponseHeaders(headers ...string) Option {
+	return OptionFunc(func(c *Config) {
+		c.CapturedResponseHeaders = append(c.CapturedResponseHeaders, headers...)
+	})
+}
+
+// WithCapturedQueryParameters adds parameters to the allowlist of request
+// query parameters recorded as span attributes, keyed with
+// QueryParameterKeyPrefix followed by the lowercased parameter name.
+func WithCapturedQueryParameters(params ...string) Option {
+	return OptionFunc(func(c *Config) {
+		c.CapturedQueryParameters = append(c.CapturedQueryParameters, params...)
+	})
+}
+
+// WithRedactedHeaders replaces the list of headers whose captured values are
+// recorded as RedactedValue. If this option isn't specified then
+// DefaultRedactedHeaders are redacted.
+func WithRedactedHeaders(headers ...string) Option {
+	return OptionFunc(func(c *Config) {
+		c.RedactedHeaders = headers
+	})
+}
+
+// WithPanicRecovery configures the Handler to recover from panics of the
+// wrapped handler. A recovered panic is recorded on the span as an exception
+// event with its stack trace, sets an error status and is recorded as a 500
+// response in the metrics. If repanic is true the panic is then propagated,
+// otherwise a 500 Internal Server Error response is written, if the handler
+// has not written a response header yet.
+func WithPanicRecovery(repanic bool) Option {
+	return OptionFunc(func(c *Config) {
+		c.RecoverPanics = true
+		c.Repanic = repanic
+	})
+}
+
+// WithClientTrace configures the Transport to attach an httptrace.ClientTrace
+// to each request, recording the connection, DNS, TLS, headers, send and
+// receive phases with the Transport's tracer. The phase mode of
+// otelhttptrace.WithPhaseMode selects whether phases are recorded as child
+// spans, as events or as duration attributes of the client span.
+func WithClientTrace(opts ...otelhttptrace.Option) Option {
+	return OptionFunc(func(c *Config) {
+		c.ClientTrace = true
+		c.ClientTraceOptions = append(c.ClientTraceOptions, opts...)
+	})
+}
@@ -5,6 +5,7 @@ go 1.14
 replace (
 	go.opentelemetry.io/contrib => ../../../../
 	go.opentelemetry.io/contrib/instrumentation/net/http => ../
+	go.opentelemetry.io/contrib/instrumentation/net/http/httptrace => ../httptrace
 )
 
 require (
@@ -17,7 +17,9 @@
 package filters
 
 import (
+	"net"
 	"net/http"
+	"regexp"
 	"strings"
 
 	otelhttp "go.opentelemetry.io/contrib/instrumentation/net/http"
@@ -93,6 +95,14 @@ func PathPrefix(p string) otelhttp.Filter {
 	}
 }
 
+// PathRegex returns a Filter that returns true if the request's
+// path matches the provided regular expression.
+func PathRegex(re *regexp.Regexp) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		return re.MatchString(r.URL.Path)
+	}
+}
+
 // Query returns a Filter that returns true if the request
 // includes a query parameter k with a value equal to v.
 func Query(k, v string) otelhttp.Filter {
@@ -126,3 +136,46 @@ func Method(m string) otelhttp.Filter {
 		return m == r.Method
 	}
 }
+
+// UserAgent returns a Filter that returns true if the request's
+// User-Agent header is equal to the provided value.
+func UserAgent(ua string) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		return ua == r.UserAgent()
+	}
+}
+
+// UserAgentContains returns a Filter that returns true if the request's
+// User-Agent header contains the provided value.
+func UserAgentContains(ua string) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		return strings.Contains(r.UserAgent(), ua)
+	}
+}
+
+// UserAgentRegex returns a Filter that returns true if the request's
+// User-Agent header matches the provided regular expression.
+func UserAgentRegex(re *regexp.Regexp) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		return re.MatchString(r.UserAgent())
+	}
+}
+
+// RemoteCIDR returns a Filter that returns true if the IP address of the
+// request's RemoteAddr is within the provided network.
+func RemoteCIDR(n *net.IPNet) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		ip := remoteIP(r)
+		return ip != nil && n.Contains(ip)
+	}
+}
+
+// remoteIP returns the IP address of the request's RemoteAddr, which may or
+// may not include a port. It returns nil if the address cannot be parsed.
+func remoteIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
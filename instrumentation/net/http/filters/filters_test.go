@@ -15,8 +15,10 @@
 package filters
 
 import (
+	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"testing"
 
 	otelhttp "go.opentelemetry.io/contrib/instrumentation/net/http"
@@ -264,3 +266,133 @@ func TestHeaderContains(t *testing.T) {
 		}
 	}
 }
+
+func TestPathRegex(t *testing.T) {
+	for _, s := range []scenario{
+		{
+			name:   "non-matching path",
+			filter: PathRegex(regexp.MustCompile(`^/health(z)?$`)),
+			req:    &http.Request{URL: &url.URL{Path: "/healthcheck"}},
+			exp:    false,
+		},
+		{
+			name:   "matching path",
+			filter: PathRegex(regexp.MustCompile(`^/health(z)?$`)),
+			req:    &http.Request{URL: &url.URL{Path: "/healthz"}},
+			exp:    true,
+		},
+	} {
+		res := s.filter(s.req)
+		if s.exp != res {
+			t.Errorf("Failed testing %q. Expected %t, got %t", s.name, s.exp, res)
+		}
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	probe := http.Header{}
+	probe.Set("User-Agent", "kube-probe/1.18")
+	browser := http.Header{}
+	browser.Set("User-Agent", "Mozilla/5.0")
+	for _, s := range []scenario{
+		{
+			name:   "non-matching user agent",
+			filter: UserAgent("kube-probe/1.18"),
+			req:    &http.Request{Header: browser},
+			exp:    false,
+		},
+		{
+			name:   "matching user agent",
+			filter: UserAgent("kube-probe/1.18"),
+			req:    &http.Request{Header: probe},
+			exp:    true,
+		},
+		{
+			name:   "non-matching user agent substring",
+			filter: UserAgentContains("kube-probe"),
+			req:    &http.Request{Header: browser},
+			exp:    false,
+		},
+		{
+			name:   "matching user agent substring",
+			filter: UserAgentContains("kube-probe"),
+			req:    &http.Request{Header: probe},
+			exp:    true,
+		},
+		{
+			name:   "matching user agent regex",
+			filter: UserAgentRegex(regexp.MustCompile(`^kube-probe/`)),
+			req:    &http.Request{Header: probe},
+			exp:    true,
+		},
+	} {
+		res := s.filter(s.req)
+		if s.exp != res {
+			t.Errorf("Failed testing %q. Expected %t, got %t", s.name, s.exp, res)
+		}
+	}
+}
+
+func TestRemoteCIDR(t *testing.T) {
+	_, n, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []scenario{
+		{
+			name:   "address outside of the network",
+			filter: RemoteCIDR(n),
+			req:    &http.Request{RemoteAddr: "192.168.0.1:1234"},
+			exp:    false,
+		},
+		{
+			name:   "address within the network",
+			filter: RemoteCIDR(n),
+			req:    &http.Request{RemoteAddr: "10.1.2.3:1234"},
+			exp:    true,
+		},
+		{
+			name:   "address without port",
+			filter: RemoteCIDR(n),
+			req:    &http.Request{RemoteAddr: "10.1.2.3"},
+			exp:    true,
+		},
+		{
+			name:   "invalid address",
+			filter: RemoteCIDR(n),
+			req:    &http.Request{RemoteAddr: "pipe"},
+			exp:    false,
+		},
+	} {
+		res := s.filter(s.req)
+		if s.exp != res {
+			t.Errorf("Failed testing %q. Expected %t, got %t", s.name, s.exp, res)
+		}
+	}
+}
+
+func TestHeaderRegex(t *testing.T) {
+	matching := http.Header{}
+	matching.Add("key", "value-1")
+	nonMatching := http.Header{}
+	nonMatching.Add("key", "other")
+	for _, s := range []scenario{
+		{
+			name:   "non-matching header",
+			filter: HeaderRegex("key", regexp.MustCompile(`^value-\d+$`)),
+			req:    &http.Request{Header: nonMatching},
+			exp:    false,
+		},
+		{
+			name:   "matching header",
+			filter: HeaderRegex("key", regexp.MustCompile(`^value-\d+$`)),
+			req:    &http.Request{Header: matching},
+			exp:    true,
+		},
+	} {
+		res := s.filter(s.req)
+		if s.exp != res {
+			t.Errorf("Failed testing %q. Expected %t, got %t", s.name, s.exp, res)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package filters
 
 import (
 	"net/http"
+	"regexp"
 	"strings"
 
 	otelhttp "go.opentelemetry.io/contrib/instrumentation/net/http"
@@ -48,3 +49,17 @@ func HeaderContains(k, v string) otelhttp.Filter {
 		return false
 	}
 }
+
+// HeaderRegex returns a Filter that returns true if the request
+// includes a header k with a value that matches the provided
+// regular expression.
+func HeaderRegex(k string, re *regexp.Regexp) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		for _, hv := range r.Header.Values(k) {
+			if re.MatchString(hv) {
+				return true
+			}
+		}
+		return false
+	}
+}
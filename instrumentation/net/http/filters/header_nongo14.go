@@ -19,6 +19,7 @@ package filters
 import (
 	"net/http"
 	"net/textproto"
+	"regexp"
 	"strings"
 
 	otelhttp "go.opentelemetry.io/contrib/instrumentation/net/http"
@@ -49,3 +50,17 @@ func HeaderContains(k, v string) otelhttp.Filter {
 		return false
 	}
 }
+
+// HeaderRegex returns a Filter that returns true if the request
+// includes a header k with a value that matches the provided
+// regular expression.
+func HeaderRegex(k string, re *regexp.Regexp) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		for _, hv := range r.Header[textproto.CanonicalMIMEHeaderKey(k)] {
+			if re.MatchString(hv) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filters
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	otelhttp "go.opentelemetry.io/contrib/instrumentation/net/http"
+)
+
+// Parse returns the Filter described by expr, so that filters can be loaded
+// from configuration. An expression is made of comparisons combined with
+// `&&`, `||`, `!` and parentheses, for example:
+//
+//	method == "GET" && path ~ "^/health"
+//	!(header["X-Internal"] == "true" || remote_addr in "10.0.0.0/8")
+//
+// A comparison is a field, an operator and a double-quoted string. The
+// fields are `method`, `path`, `host`, `user_agent`, `remote_addr`,
+// `header["name"]` and `query["name"]`. The operators are `==` and `!=` for
+// equality, `~` and `!~` for regular expression matching, and `in` for
+// matching `remote_addr` against a CIDR block. A header or query field
+// matches if any of its values does.
+func Parse(expr string) (otelhttp.Filter, error) {
+	p := &parser{lexer: lexer{input: expr}}
+	p.next()
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return f, nil
+}
+
+// MustParse is like Parse but panics if expr cannot be parsed.
+func MustParse(expr string) otelhttp.Filter {
+	f, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenOp
+	tokenInvalid
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return fmt.Sprintf("string %s", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// operators lists the operator tokens, longest first so that `!=` and `!~`
+// are not lexed as `!`.
+var operators = []string{"&&", "||", "==", "!=", "!~", "!", "~", "(", ")", "[", "]"}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() token {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}
+	}
+
+	rest := l.input[l.pos:]
+	switch c := rest[0]; {
+	case c == '"':
+		for i := 1; i < len(rest); i++ {
+			switch rest[i] {
+			case '\\':
+				i++
+			case '"':
+				l.pos += i + 1
+				return token{kind: tokenString, text: rest[:i+1], pos: start}
+			}
+		}
+		l.pos = len(l.input)
+		return token{kind: tokenInvalid, text: rest, pos: start}
+	case c == '_' || unicode.IsLetter(rune(c)):
+		i := 1
+		for i < len(rest) && (rest[i] == '_' || unicode.IsLetter(rune(rest[i])) || unicode.IsDigit(rune(rest[i]))) {
+			i++
+		}
+		l.pos += i
+		return token{kind: tokenIdent, text: rest[:i], pos: start}
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			l.pos += len(op)
+			return token{kind: tokenOp, text: op, pos: start}
+		}
+	}
+	l.pos++
+	return token{kind: tokenInvalid, text: rest[:1], pos: start}
+}
+
+type parser struct {
+	lexer lexer
+	tok   token
+}
+
+func (p *parser) next() {
+	p.tok = p.lexer.next()
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("filters: parsing %q at offset %d: %s", p.lexer.input, p.tok.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) isOp(op string) bool {
+	return p.tok.kind == tokenOp && p.tok.text == op
+}
+
+func (p *parser) expectOp(op string) error {
+	if !p.isOp(op) {
+		return p.errorf("expected %q, found %s", op, p.tok)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseOr() (otelhttp.Filter, error) {
+	f, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	fs := []otelhttp.Filter{f}
+	for p.isOp("||") {
+		p.next()
+		if f, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		fs = append(fs, f)
+	}
+	if len(fs) == 1 {
+		return fs[0], nil
+	}
+	return Any(fs...), nil
+}
+
+func (p *parser) parseAnd() (otelhttp.Filter, error) {
+	f, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	fs := []otelhttp.Filter{f}
+	for p.isOp("&&") {
+		p.next()
+		if f, err = p.parseUnary(); err != nil {
+			return nil, err
+		}
+		fs = append(fs, f)
+	}
+	if len(fs) == 1 {
+		return fs[0], nil
+	}
+	return All(fs...), nil
+}
+
+func (p *parser) parseUnary() (otelhttp.Filter, error) {
+	switch {
+	case p.isOp("!"):
+		p.next()
+		f, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(f), nil
+	case p.isOp("("):
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	return p.parseComparison()
+}
+
+// field returns the values of a request field compared by an expression.
+type field func(r *http.Request) []string
+
+func (p *parser) parseField() (string, field, error) {
+	if p.tok.kind != tokenIdent {
+		return "", nil, p.errorf("expected a field, found %s", p.tok)
+	}
+	name, pos := p.tok.text, p.tok.pos
+	p.next()
+
+	switch name {
+	case "method":
+		return name, func(r *http.Request) []string { return []string{r.Method} }, nil
+	case "path":
+		return name, func(r *http.Request) []string { return []string{r.URL.Path} }, nil
+	case "host":
+		return name, func(r *http.Request) []string { return []string{r.URL.Hostname()} }, nil
+	case "user_agent":
+		return name, func(r *http.Request) []string { return []string{r.UserAgent()} }, nil
+	case "remote_addr":
+		return name, nil, nil
+	case "header", "query":
+		if err := p.expectOp("["); err != nil {
+			return "", nil, err
+		}
+		key, err := p.parseString()
+		if err != nil {
+			return "", nil, err
+		}
+		if err := p.expectOp("]"); err != nil {
+			return "", nil, err
+		}
+		if name == "header" {
+			key = textproto.CanonicalMIMEHeaderKey(key)
+			return name, func(r *http.Request) []string { return r.Header[key] }, nil
+		}
+		return name, func(r *http.Request) []string { return r.URL.Query()[key] }, nil
+	}
+	return "", nil, fmt.Errorf("filters: parsing %q at offset %d: unknown field %q", p.lexer.input, pos, name)
+}
+
+func (p *parser) parseString() (string, error) {
+	if p.tok.kind != tokenString {
+		return "", p.errorf("expected a string, found %s", p.tok)
+	}
+	s, err := strconv.Unquote(p.tok.text)
+	if err != nil {
+		return "", p.errorf("invalid string %s", p.tok.text)
+	}
+	p.next()
+	return s, nil
+}
+
+func (p *parser) parseComparison() (otelhttp.Filter, error) {
+	name, values, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.tok
+	if name == "remote_addr" {
+		if op.kind != tokenIdent || op.text != "in" {
+			return nil, p.errorf("expected \"in\" after remote_addr, found %s", op)
+		}
+		p.next()
+		cidr, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("filters: parsing %q: %w", p.lexer.input, err)
+		}
+		return RemoteCIDR(n), nil
+	}
+
+	if op.kind != tokenOp {
+		return nil, p.errorf("expected an operator after %s, found %s", name, op)
+	}
+	p.next()
+	arg, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.text {
+	case "==", "!=":
+		f := anyValue(values, func(v string) bool { return v == arg })
+		if op.text == "!=" {
+			f = Not(f)
+		}
+		return f, nil
+	case "~", "!~":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("filters: parsing %q: %w", p.lexer.input, err)
+		}
+		f := anyValue(values, re.MatchString)
+		if op.text == "!~" {
+			f = Not(f)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("filters: parsing %q: unexpected operator %q after %s", p.lexer.input, op.text, name)
+}
+
+// anyValue returns a Filter that returns true if match returns true for any
+// of the values of a request field.
+func anyValue(values field, match func(string) bool) otelhttp.Filter {
+	return func(r *http.Request) bool {
+		for _, v := range values(r) {
+			if match(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
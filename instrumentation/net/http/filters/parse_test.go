@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filters
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	probe := http.Header{}
+	probe.Set("User-Agent", "kube-probe/1.18")
+	probe.Set("X-Internal", "true")
+
+	for _, s := range []struct {
+		name string
+		expr string
+		req  *http.Request
+		exp  bool
+	}{
+		{
+			name: "method and path regex",
+			expr: `method == "GET" && path ~ "^/health"`,
+			req:  &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/healthz"}},
+			exp:  true,
+		},
+		{
+			name: "method mismatch",
+			expr: `method == "GET" && path ~ "^/health"`,
+			req:  &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/healthz"}},
+			exp:  false,
+		},
+		{
+			name: "or with negated regex",
+			expr: `path == "/metrics" || path !~ "^/api/"`,
+			req:  &http.Request{URL: &url.URL{Path: "/api/users"}},
+			exp:  false,
+		},
+		{
+			name: "inequality",
+			expr: `host != "internal.example.com"`,
+			req:  &http.Request{URL: &url.URL{Host: "example.com:8080"}},
+			exp:  true,
+		},
+		{
+			name: "header is case insensitive",
+			expr: `header["x-internal"] == "true"`,
+			req:  &http.Request{Header: probe},
+			exp:  true,
+		},
+		{
+			name: "query parameter",
+			expr: `query["debug"] == "1"`,
+			req:  &http.Request{URL: &url.URL{RawQuery: "debug=0&debug=1"}},
+			exp:  true,
+		},
+		{
+			name: "user agent",
+			expr: `user_agent ~ "^kube-probe/"`,
+			req:  &http.Request{Header: probe},
+			exp:  true,
+		},
+		{
+			name: "remote address",
+			expr: `remote_addr in "10.0.0.0/8"`,
+			req:  &http.Request{RemoteAddr: "10.1.2.3:1234"},
+			exp:  true,
+		},
+		{
+			name: "negated group",
+			expr: `!(remote_addr in "10.0.0.0/8" || user_agent == "curl")`,
+			req:  &http.Request{RemoteAddr: "192.168.0.1:1234", Header: probe},
+			exp:  true,
+		},
+		{
+			name: "and binds tighter than or",
+			expr: `path == "/a" || path == "/b" && method == "POST"`,
+			req:  &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/a"}},
+			exp:  true,
+		},
+		{
+			name: "escaped string",
+			expr: `path == "/say \"hi\""`,
+			req:  &http.Request{URL: &url.URL{Path: `/say "hi"`}},
+			exp:  true,
+		},
+	} {
+		f, err := Parse(s.expr)
+		if err != nil {
+			t.Errorf("Failed parsing %q: %v", s.name, err)
+			continue
+		}
+		res := f(s.req)
+		if s.exp != res {
+			t.Errorf("Failed testing %q. Expected %t, got %t", s.name, s.exp, res)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		``,
+		`method`,
+		`method == GET`,
+		`method = "GET"`,
+		`verb == "GET"`,
+		`path ~ "("`,
+		`remote_addr == "10.0.0.1"`,
+		`remote_addr in "10.0.0.0"`,
+		`header[x] == "1"`,
+		`(method == "GET"`,
+		`method == "GET" extra`,
+		`path == "unterminated`,
+		`method == "GET" & path == "/"`,
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Expected an error parsing %q", expr)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustParse to panic on an invalid expression")
+		}
+	}()
+	MustParse(`method ==`)
+}
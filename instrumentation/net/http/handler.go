@@ -15,12 +15,16 @@
 package http
 
 import (
+	"context"
 	"io"
 	"net/http"
+	"runtime/debug"
 	"time"
 
 	"github.com/felixge/httpsnoop"
 
+	"go.opentelemetry.io/contrib/internal/panics"
+
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/kv"
 	"go.opentelemetry.io/otel/api/metric"
@@ -47,16 +51,28 @@ type Handler struct {
 	writeEvent        bool
 	filters           []Filter
 	spanNameFormatter func(string, *http.Request) string
+	routeResolver     RouteResolver
+	samplingHint      SamplingHint
+	requestHeaders    valueCapture
+	responseHeaders   valueCapture
+	queryParameters   valueCapture
+	bodyCapture       *BodyCapture
+	recoverPanics     bool
+	repanic           bool
 	counters          map[string]metric.Int64Counter
 	valueRecorders    map[string]metric.Int64ValueRecorder
 }
 
-func defaultHandlerFormatter(operation string, _ *http.Request) string {
+func defaultHandlerFormatter(operation string, r *http.Request) string {
+	if route := RouteFromContext(r.Context()); route != "" {
+		return route
+	}
 	return operation
 }
 
 // NewHandler wraps the passed handler, functioning like middleware, in a span
-// named after the operation and with any provided Options.
+// named after the operation, or after the route template when a RouteResolver
+// is provided, and with any provided Options.
 func NewHandler(handler http.Handler, operation string, opts ...Option) http.Handler {
 	h := Handler{
 		handler:   handler,
@@ -71,6 +87,7 @@ func NewHandler(handler http.Handler, operation string, opts ...Option) http.Han
 		WithPropagators(global.Propagators()),
 		WithSpanOptions(trace.WithSpanKind(trace.SpanKindServer)),
 		WithSpanNameFormatter(defaultHandlerFormatter),
+		WithRedactedHeaders(DefaultRedactedHeaders...),
 	}
 
 	c := NewConfig(append(defaultOpts, opts...)...)
@@ -89,6 +106,14 @@ func (h *Handler) configure(c *Config) {
 	h.writeEvent = c.WriteEvent
 	h.filters = c.Filters
 	h.spanNameFormatter = c.SpanNameFormatter
+	h.routeResolver = c.RouteResolver
+	h.samplingHint = c.SamplingHint
+	h.requestHeaders = newHeaderCapture(RequestHeaderKeyPrefix, c.CapturedRequestHeaders, c.RedactedHeaders)
+	h.responseHeaders = newHeaderCapture(ResponseHeaderKeyPrefix, c.CapturedResponseHeaders, c.RedactedHeaders)
+	h.queryParameters = newQueryCapture(c.CapturedQueryParameters)
+	h.bodyCapture = c.BodyCapture
+	h.recoverPanics = c.RecoverPanics
+	h.repanic = c.Repanic
 }
 
 func handleErr(err error) {
@@ -110,8 +135,12 @@ func (h *Handler) createMeasures() {
 	serverLatencyMeasure, err := h.meter.NewInt64ValueRecorder(ServerLatency)
 	handleErr(err)
 
+	serverPanicsCounter, err := h.meter.NewInt64Counter(ServerPanics)
+	handleErr(err)
+
 	h.counters[RequestContentLength] = requestBytesCounter
 	h.counters[ResponseContentLength] = responseBytesCounter
+	h.counters[ServerPanics] = serverPanicsCounter
 	h.valueRecorders[ServerLatency] = serverLatencyMeasure
 }
 
@@ -126,11 +155,27 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	samplingOpts, ok := samplingOptions(h.samplingHint, r)
+	if !ok {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	route := ""
+	if h.routeResolver != nil {
+		if route = h.routeResolver.ResolveRoute(r); route != "" {
+			r = r.WithContext(ContextWithRoute(r.Context(), route))
+		}
+	}
+
 	opts := append([]trace.StartOption{
 		trace.WithAttributes(standard.NetAttributesFromHTTPRequest("tcp", r)...),
 		trace.WithAttributes(standard.EndUserAttributesFromHTTPRequest(r)...),
-		trace.WithAttributes(standard.HTTPServerAttributesFromHTTPRequest(h.operation, "", r)...),
+		trace.WithAttributes(standard.HTTPServerAttributesFromHTTPRequest(h.operation, route, r)...),
+		trace.WithAttributes(h.requestHeaders.headerAttributes(r.Header)...),
+		trace.WithAttributes(h.queryParameters.queryAttributes(r.URL)...),
 	}, h.spanStartOptions...) // start with the configured options
+	opts = append(opts, samplingOpts...)
 
 	ctx := propagation.ExtractHTTP(r.Context(), h.propagators, r.Header)
 	ctx, span := h.tracer.Start(ctx, h.spanNameFormatter(h.operation, r), opts...)
@@ -145,6 +190,14 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	bw := bodyWrapper{ReadCloser: r.Body, record: readRecordFunc}
 	r.Body = &bw
 
+	var rwwCapture *bodyBuffer
+	if h.bodyCapture != nil {
+		if _, ok := h.bodyCapture.allows(r.Header.Get("Content-Type")); ok {
+			bw.capture = newBodyBuffer(h.bodyCapture.maxSize())
+		}
+		rwwCapture = newBodyBuffer(h.bodyCapture.maxSize())
+	}
+
 	writeRecordFunc := func(int64) {}
 	if h.writeEvent {
 		writeRecordFunc = func(n int64) {
@@ -152,7 +205,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	rww := &respWriterWrapper{ResponseWriter: w, record: writeRecordFunc, ctx: ctx, props: h.propagators}
+	rww := &respWriterWrapper{ResponseWriter: w, record: writeRecordFunc, capture: rwwCapture, ctx: ctx, props: h.propagators}
 
 	// Wrap w to use our ResponseWriter methods while also exposing
 	// other interfaces that w may implement (http.CloseNotifier,
@@ -170,13 +223,43 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		},
 	})
 
+	if h.recoverPanics {
+		defer func() {
+			if p := recover(); p != nil {
+				if !h.repanic && !rww.wroteHeader {
+					rww.WriteHeader(http.StatusInternalServerError)
+				}
+				rww.statusCode = http.StatusInternalServerError
+				h.finish(ctx, span, r, &bw, rww, requestStartTime, route)
+				h.counters[ServerPanics].Add(ctx, 1, append(h.metricLabels(r, route),
+					standard.HTTPStatusCodeKey.Int(http.StatusInternalServerError))...)
+				panics.Record(ctx, span, p, debug.Stack())
+				if h.repanic {
+					panic(p)
+				}
+			}
+		}()
+	}
+
 	h.handler.ServeHTTP(w, r.WithContext(ctx))
 
+	h.finish(ctx, span, r, &bw, rww, requestStartTime, route)
+}
+
+// finish records the span attributes and events and the metrics of a served
+// request.
+func (h *Handler) finish(ctx context.Context, span trace.Span, r *http.Request, bw *bodyWrapper, rww *respWriterWrapper, requestStartTime time.Time, route string) {
 	setAfterServeAttributes(span, bw.read, rww.written, rww.statusCode, bw.err, rww.err)
+	span.SetAttributes(h.responseHeaders.headerAttributes(rww.Header())...)
+
+	if h.bodyCapture != nil && h.bodyCapture.shouldRecord(r, rww.statusCode) {
+		h.bodyCapture.record(ctx, span, "http.request.body", bw.capture, r.Header.Get("Content-Type"))
+		h.bodyCapture.record(ctx, span, "http.response.body", rww.capture, rww.Header().Get("Content-Type"))
+	}
 
 	// Add request metrics
 
-	labels := standard.HTTPServerMetricAttributesFromHTTPRequest(h.operation, r)
+	labels := h.metricLabels(r, route)
 
 	h.counters[RequestContentLength].Add(ctx, bw.read, labels...)
 	h.counters[ResponseContentLength].Add(ctx, rww.written, labels...)
@@ -186,6 +269,15 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.valueRecorders[ServerLatency].Record(ctx, elapsedTime, labels...)
 }
 
+// metricLabels returns the labels of the metrics of r, served by route.
+func (h *Handler) metricLabels(r *http.Request, route string) []kv.KeyValue {
+	labels := standard.HTTPServerMetricAttributesFromHTTPRequest(h.operation, r)
+	if route != "" {
+		labels = append(labels, standard.HTTPRouteKey.String(route))
+	}
+	return labels
+}
+
 func setAfterServeAttributes(span trace.Span, read, wrote int64, statusCode int, rerr, werr error) {
 	kv := []kv.KeyValue{}
 
@@ -162,3 +162,64 @@ func TestResponseWriterOptionalInterfaces(t *testing.T) {
 		t.Fatal("http.Flusher interface not exposed")
 	}
 }
+
+func TestHandlerPanicRecovery(t *testing.T) {
+	for _, repanic := range []bool{false, true} {
+		t.Run(fmt.Sprintf("repanic=%t", repanic), func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			var span *mocktrace.Span
+			tracer := mocktrace.Tracer{
+				OnSpanStarted: func(s *mocktrace.Span) {
+					span = s
+				},
+			}
+			meterimpl, meter := mockmeter.NewMeter()
+
+			h := NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					panic("boom")
+				}), "test_handler",
+				WithTracer(&tracer),
+				WithMeter(meter),
+				WithPanicRecovery(repanic),
+			)
+
+			r, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			serve := func() { h.ServeHTTP(rr, r) }
+			if repanic {
+				assert.PanicsWithValue(t, "boom", serve)
+			} else {
+				assert.NotPanics(t, serve)
+				assert.Equal(t, http.StatusInternalServerError, rr.Result().StatusCode)
+			}
+
+			if assert.NotNil(t, span) {
+				assert.Equal(t, codes.Internal, span.Status)
+				assert.Equal(t, "boom", span.StatusMessage)
+				if assert.Len(t, span.Events, 1) {
+					assert.Equal(t, "exception", span.Events[0].Name)
+					assert.Equal(t, "string", span.Events[0].Attributes[ExceptionTypeKey].AsString())
+					assert.Equal(t, "boom", span.Events[0].Attributes[ExceptionMessageKey].AsString())
+					assert.Contains(t, span.Events[0].Attributes[ExceptionStacktraceKey].AsString(), "TestHandlerPanicRecovery")
+				}
+			}
+
+			var panicked int64
+			for _, batch := range meterimpl.MeasurementBatches {
+				for _, m := range batch.Measurements {
+					if m.Instrument.Descriptor().Name() != ServerPanics {
+						continue
+					}
+					panicked += m.Number.AsInt64()
+					assert.Contains(t, batch.Labels, standard.HTTPStatusCodeKey.Int(http.StatusInternalServerError))
+				}
+			}
+			assert.Equal(t, int64(1), panicked, "panicking requests are counted with a 500 status")
+		})
+	}
+}
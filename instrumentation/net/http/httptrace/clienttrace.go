@@ -21,6 +21,7 @@ import (
 	"net/textproto"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/api/standard"
 
@@ -36,6 +37,44 @@ var (
 	HTTPHeaderMIME = kv.Key("http.mime")
 	HTTPRemoteAddr = kv.Key("http.remote")
 	HTTPLocalAddr  = kv.Key("http.local")
+	HTTPError      = kv.Key("http.error")
+)
+
+// Hook identifies a phase of an outgoing request traced by a client trace.
+// Its value is the name of the span of the phase.
+type Hook string
+
+// Hooks of a client trace
+const (
+	HookGetConn Hook = "http.getconn" // Connection acquisition, parent of DNS, connect and TLS
+	HookDNS     Hook = "http.dns"     // DNS lookup
+	HookConnect Hook = "http.connect" // Dial of an address
+	HookTLS     Hook = "http.tls"     // TLS handshake
+	HookHeaders Hook = "http.headers" // Writing of the request headers
+	HookSend    Hook = "http.send"    // Writing of the request body
+	HookReceive Hook = "http.receive" // Response, from its first byte until the connection is idle
+)
+
+// PhaseMode selects how the phases of an outgoing request (connection
+// acquisition, DNS, connect, TLS handshake, headers, send and receive) are
+// recorded by a client trace.
+type PhaseMode int
+
+const (
+	// PhaseSpans records each phase as a child span.
+	PhaseSpans PhaseMode = iota
+	// PhaseEvents records the start and end of each phase as events of the
+	// span found in the context of the client trace.
+	PhaseEvents
+	// PhaseAttributes records the duration of each phase, in microseconds,
+	// as an attribute of the span found in the context of the client trace,
+	// keyed by the phase name followed by ".duration". The other attributes
+	// of the phases are keyed by the phase name too, so that http.host of
+	// the connection acquisition is recorded as http.getconn.host.
+	PhaseAttributes
+	// PhaseNone records nothing on spans, for client traces that only
+	// record metrics with WithMeter.
+	PhaseNone
 )
 
 var (
@@ -58,18 +97,51 @@ type clientTracer struct {
 
 	tr trace.Tracer
 
-	activeHooks map[string]context.Context
-	root        trace.Span
-	mtx         sync.Mutex
+	mode           PhaseMode
+	metrics        *clientMetrics
+	hooks          map[Hook]bool
+	headers        headerFilter
+	getConnNewRoot bool
+
+	activeHooks  map[string]context.Context
+	startTimes   map[string]time.Time
+	metricStarts map[string]time.Time
+	host         string
+	root         trace.Span
+	mtx          sync.Mutex
 }
 
-func NewClientTrace(ctx context.Context) *httptrace.ClientTrace {
+// NewClientTrace returns an httptrace.ClientTrace recording the phases of
+// the requests made with ctx, as configured by opts. By default each phase is
+// recorded as a span created with the global tracer.
+func NewClientTrace(ctx context.Context, opts ...Option) *httptrace.ClientTrace {
+	c := newConfig(opts)
 	ct := &clientTracer{
-		Context:     ctx,
-		activeHooks: make(map[string]context.Context),
+		Context:        ctx,
+		mode:           c.phaseMode,
+		metrics:        c.metrics,
+		hooks:          c.hooks,
+		headers:        c.headers,
+		getConnNewRoot: c.getConnNewRoot,
+		activeHooks:    make(map[string]context.Context),
+		startTimes:     make(map[string]time.Time),
+		metricStarts:   make(map[string]time.Time),
 	}
 
-	ct.tr = global.Tracer("go.opentelemetry.io/otel/instrumentation/httptrace")
+	ct.tr = c.tracer
+	if ct.tr == nil {
+		provider := c.provider
+		if provider == nil {
+			provider = global.TraceProvider()
+		}
+		ct.tr = provider.Tracer(instrumentationName)
+	}
+	switch ct.mode {
+	case PhaseNone:
+		ct.root = trace.NoopSpan{}
+	case PhaseEvents, PhaseAttributes:
+		ct.root = trace.SpanFromContext(ctx)
+	}
 
 	return &httptrace.ClientTrace{
 		GetConn:              ct.getConn,
@@ -95,10 +167,31 @@ func (ct *clientTracer) start(hook, spanName string, attrs ...kv.KeyValue) {
 	ct.mtx.Lock()
 	defer ct.mtx.Unlock()
 
+	if ct.metrics != nil {
+		ct.metricStarts[hook] = time.Now()
+	}
+
+	if !ct.traces(hook) {
+		return
+	}
+
+	switch ct.mode {
+	case PhaseNone:
+		return
+	case PhaseEvents:
+		ct.startTimes[hook] = time.Now()
+		ct.root.AddEvent(ct.Context, spanName+".start", attrs...)
+		return
+	case PhaseAttributes:
+		ct.startTimes[hook] = time.Now()
+		ct.root.SetAttributes(phaseAttributes(phaseName(hook), attrs)...)
+		return
+	}
+
 	if hookCtx, found := ct.activeHooks[hook]; !found {
 		var sp trace.Span
-		ct.activeHooks[hook], sp = ct.tr.Start(ct.getParentContext(hook), spanName, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindClient))
-		if ct.root == nil {
+		ct.activeHooks[hook], sp = ct.tr.Start(ct.getParentContext(hook), spanName, ct.spanOptions(hook, attrs)...)
+		if ct.root == nil && !(ct.getConnNewRoot && hook == string(HookGetConn)) {
 			ct.root = sp
 		}
 	} else {
@@ -114,6 +207,23 @@ func (ct *clientTracer) start(hook, spanName string, attrs ...kv.KeyValue) {
 func (ct *clientTracer) end(hook string, err error, attrs ...kv.KeyValue) {
 	ct.mtx.Lock()
 	defer ct.mtx.Unlock()
+
+	if ct.metrics != nil {
+		ct.endMetrics(hook, err)
+	}
+
+	if !ct.traces(hook) {
+		return
+	}
+
+	switch ct.mode {
+	case PhaseNone:
+		return
+	case PhaseEvents, PhaseAttributes:
+		ct.endPhase(hook, err, attrs)
+		return
+	}
+
 	if ctx, ok := ct.activeHooks[hook]; ok {
 		span := trace.SpanFromContext(ctx)
 		if err != nil {
@@ -126,7 +236,7 @@ func (ct *clientTracer) end(hook string, err error, attrs ...kv.KeyValue) {
 		// start is not finished before end is called.
 		// Start a span here with the ending attributes that will be finished when start finishes.
 		// Yes, it's backwards. v0v
-		ctx, span := ct.tr.Start(ct.getParentContext(hook), hook, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span := ct.tr.Start(ct.getParentContext(hook), phaseName(hook), ct.spanOptions(hook, attrs)...)
 		if err != nil {
 			span.SetStatus(codes.Unknown, err.Error())
 		}
@@ -134,6 +244,92 @@ func (ct *clientTracer) end(hook string, err error, attrs ...kv.KeyValue) {
 	}
 }
 
+// traces reports whether the phase traced by hook is recorded on spans.
+func (ct *clientTracer) traces(hook string) bool {
+	return ct.hooks == nil || ct.hooks[Hook(phaseName(hook))]
+}
+
+// spanOptions returns the options used to start the span of hook.
+func (ct *clientTracer) spanOptions(hook string, attrs []kv.KeyValue) []trace.StartOption {
+	opts := []trace.StartOption{trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindClient)}
+	if ct.getConnNewRoot && hook == string(HookGetConn) {
+		opts = append(opts, trace.WithNewRoot())
+	}
+	return opts
+}
+
+// rootSpan returns the span holding request-wide attributes: the first phase
+// span, or the span of the client trace context when there is none yet.
+func (ct *clientTracer) rootSpan() trace.Span {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+	if ct.root == nil {
+		return trace.SpanFromContext(ct.Context)
+	}
+	return ct.root
+}
+
+// endPhase records the end of a phase on the root span, as an event or as
+// attributes depending on the mode. The caller must hold ct.mtx.
+func (ct *clientTracer) endPhase(hook string, err error, attrs []kv.KeyValue) {
+	name := phaseName(hook)
+	if err != nil {
+		if ct.mode == PhaseEvents {
+			attrs = append(attrs, HTTPError.String(err.Error()))
+		} else {
+			attrs = append(attrs, kv.String(name+".error", err.Error()))
+		}
+	}
+
+	startTime, started := ct.startTimes[hook]
+	delete(ct.startTimes, hook)
+
+	if ct.mode == PhaseEvents {
+		ct.root.AddEvent(ct.Context, name+".done", attrs...)
+		return
+	}
+	attrs = phaseAttributes(name, attrs)
+	if started {
+		attrs = append(attrs, kv.Int64(name+".duration", time.Since(startTime).Microseconds()))
+	}
+	ct.root.SetAttributes(attrs...)
+}
+
+// phaseAttributes returns attrs keyed by the phase name, so that they do not
+// overwrite the attributes of the span they are set on. Keys already prefixed
+// with the phase name are kept.
+func phaseAttributes(name string, attrs []kv.KeyValue) []kv.KeyValue {
+	prefixed := make([]kv.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		key := string(a.Key)
+		if !strings.HasPrefix(key, name+".") {
+			key = name + "." + strings.TrimPrefix(key, "http.")
+		}
+		prefixed = append(prefixed, kv.KeyValue{Key: kv.Key(key), Value: a.Value})
+	}
+	return prefixed
+}
+
+// endMetrics records the duration of the phase traced by hook and whether
+// it failed. The caller must hold ct.mtx.
+func (ct *clientTracer) endMetrics(hook string, err error) {
+	name := phaseName(hook)
+	ct.metrics.recordDuration(ct.Context, name, ct.host, ct.metricStarts[hook])
+	delete(ct.metricStarts, hook)
+	if err != nil && name == "http.connect" {
+		ct.metrics.recordConnectError(ct.Context, ct.host)
+	}
+}
+
+// phaseName returns the name of the phase traced by hook, which is the hook
+// without the address suffix of connect hooks.
+func phaseName(hook string) string {
+	if strings.HasPrefix(hook, "http.connect") {
+		return "http.connect"
+	}
+	return hook
+}
+
 func (ct *clientTracer) getParentContext(hook string) context.Context {
 	ctx, ok := ct.activeHooks[parentHook(hook)]
 	if !ok {
@@ -142,20 +338,47 @@ func (ct *clientTracer) getParentContext(hook string) context.Context {
 	return ctx
 }
 
+// span returns the span of the phase traced by hook. When the phase has no
+// span, because it is not traced or has not started, it returns the span
+// of the client trace context, which is a noop span if there is none.
 func (ct *clientTracer) span(hook string) trace.Span {
 	ct.mtx.Lock()
 	defer ct.mtx.Unlock()
+	if ct.mode != PhaseSpans {
+		return ct.root
+	}
 	if ctx, ok := ct.activeHooks[hook]; ok {
 		return trace.SpanFromContext(ctx)
 	}
-	return nil
+	return trace.SpanFromContext(ct.Context)
+}
+
+// active reports whether the phase traced by hook has started and not ended.
+func (ct *clientTracer) active(hook string) bool {
+	ct.mtx.Lock()
+	defer ct.mtx.Unlock()
+	if ct.mode != PhaseSpans {
+		_, ok := ct.startTimes[hook]
+		return ok
+	}
+	_, ok := ct.activeHooks[hook]
+	return ok
 }
 
 func (ct *clientTracer) getConn(host string) {
+	ct.mtx.Lock()
+	ct.host = host
+	ct.mtx.Unlock()
 	ct.start("http.getconn", "http.getconn", standard.HTTPHostKey.String(host))
 }
 
 func (ct *clientTracer) gotConn(info httptrace.GotConnInfo) {
+	if ct.metrics != nil {
+		ct.mtx.Lock()
+		host := ct.host
+		ct.mtx.Unlock()
+		ct.metrics.recordConnection(ct.Context, host, info.Reused, info.WasIdle, info.IdleTime)
+	}
 	ct.end("http.getconn",
 		nil,
 		HTTPRemoteAddr.String(info.Conn.RemoteAddr().String()),
@@ -196,14 +419,16 @@ func (ct *clientTracer) tlsHandshakeDone(_ tls.ConnectionState, err error) {
 }
 
 func (ct *clientTracer) wroteHeaderField(k string, v []string) {
-	if ct.span("http.headers") == nil {
+	if !ct.active("http.headers") {
 		ct.start("http.headers", "http.headers")
 	}
-	ct.root.SetAttributes(kv.String("http."+strings.ToLower(k), sliceToString(v)))
+	if v, ok := ct.headers.value(k, v); ok {
+		ct.rootSpan().SetAttributes(headerKey(k).String(v))
+	}
 }
 
 func (ct *clientTracer) wroteHeaders() {
-	if ct.span("http.headers") != nil {
+	if ct.active("http.headers") {
 		ct.end("http.headers", nil)
 	}
 	ct.start("http.send", "http.send")
@@ -211,7 +436,7 @@ func (ct *clientTracer) wroteHeaders() {
 
 func (ct *clientTracer) wroteRequest(info httptrace.WroteRequestInfo) {
 	if info.Err != nil {
-		ct.root.SetStatus(codes.Unknown, info.Err.Error())
+		ct.rootSpan().SetStatus(codes.Unknown, info.Err.Error())
 	}
 	ct.end("http.send", info.Err)
 }
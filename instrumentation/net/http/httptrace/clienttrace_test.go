@@ -14,7 +14,11 @@
 package httptrace_test
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	nhtrace "net/http/httptrace"
@@ -23,10 +27,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace"
+
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/kv"
 	"go.opentelemetry.io/otel/api/trace/testtrace"
-	"go.opentelemetry.io/otel/instrumentation/httptrace"
 )
 
 type SpanRecorder map[string]*testtrace.Span
@@ -236,3 +241,147 @@ func TestEndBeforeStartCreatesSpan(t *testing.T) {
 	spans := sr[name]
 	require.Len(t, spans, 1)
 }
+
+func TestClientTracePhaseEvents(t *testing.T) {
+	sr := MultiSpanRecorder{}
+	tr := testtrace.NewProvider(testtrace.WithSpanRecorder(&sr)).Tracer("httptrace/client")
+
+	ctx, span := tr.Start(context.Background(), "client")
+	ct := httptrace.NewClientTrace(ctx, httptrace.WithTracer(tr), httptrace.WithPhaseMode(httptrace.PhaseEvents))
+	ct.DNSStart(nhtrace.DNSStartInfo{Host: "example.com"})
+	ct.DNSDone(nhtrace.DNSDoneInfo{Err: errors.New("no such host")})
+	span.End()
+
+	require.Len(t, sr, 1, "no phase span must be created")
+	events := sr["client"][0].Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "http.dns.start", events[0].Name)
+	assert.Equal(t, kv.StringValue("example.com"), events[0].Attributes[kv.Key("http.host")])
+	assert.Equal(t, "http.dns.done", events[1].Name)
+	assert.Equal(t, kv.StringValue("no such host"), events[1].Attributes[httptrace.HTTPError])
+}
+
+func TestClientTracePhaseAttributes(t *testing.T) {
+	sr := SpanRecorder{}
+	tr := testtrace.NewProvider(testtrace.WithSpanRecorder(&sr)).Tracer("httptrace/client")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	ctx, span := tr.Start(context.Background(), "client")
+	ctx = nhtrace.WithClientTrace(ctx, httptrace.NewClientTrace(ctx,
+		httptrace.WithTracer(tr), httptrace.WithPhaseMode(httptrace.PhaseAttributes)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	res, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	span.End()
+
+	require.Len(t, sr, 1, "no phase span must be created")
+	attrs := sr["client"].Attributes()
+	for _, phase := range []string{"http.getconn", "http.connect", "http.headers", "http.send"} {
+		v, ok := attrs[kv.Key(phase+".duration")]
+		if assert.True(t, ok, phase) {
+			assert.GreaterOrEqual(t, v.AsInt64(), int64(0))
+		}
+	}
+	assert.Equal(t, kv.StringValue(ts.Listener.Addr().String()), attrs["http.getconn.remote"])
+	assert.Equal(t, kv.StringValue(ts.Listener.Addr().String()), attrs["http.getconn.host"])
+	assert.NotContains(t, attrs, httptrace.HTTPRemoteAddr)
+	assert.Contains(t, attrs, kv.Key("http.request.header.host"))
+	assert.NotContains(t, attrs, kv.Key("http.host"), "headers must not overwrite span attributes")
+}
+
+func TestClientTraceOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	do := func(t *testing.T, opts ...httptrace.Option) MultiSpanRecorder {
+		sr := MultiSpanRecorder{}
+		tp := testtrace.NewProvider(testtrace.WithSpanRecorder(&sr))
+		ctx, span := tp.Tracer("test").Start(context.Background(), "client")
+		ctx = nhtrace.WithClientTrace(ctx, httptrace.NewClientTrace(ctx,
+			append([]httptrace.Option{httptrace.WithTracerProvider(tp)}, opts...)...))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("X-Request-Id", "42")
+		res, err := (&http.Client{Transport: &http.Transport{}}).Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		span.End()
+		return sr
+	}
+
+	t.Run("hooks", func(t *testing.T) {
+		sr := do(t, httptrace.WithHooks(httptrace.HookConnect, httptrace.HookHeaders))
+		assert.Len(t, sr, 3)
+		assert.Contains(t, sr, "client")
+		require.Contains(t, sr, "http.connect")
+		require.Contains(t, sr, "http.headers")
+		assert.Equal(t, sr["client"][0].SpanContext().SpanID, sr["http.connect"][0].ParentSpanID())
+	})
+
+	t.Run("hooks with 1xx response", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			_, _ = http.ReadRequest(bufio.NewReader(conn))
+			_, _ = io.WriteString(conn, "HTTP/1.1 103 Early Hints\r\nLink: </style.css>\r\n\r\n"+
+				"HTTP/1.1 200 OK\r\nContent-Length: 0\r\nConnection: close\r\n\r\n")
+		}()
+
+		sr := MultiSpanRecorder{}
+		tp := testtrace.NewProvider(testtrace.WithSpanRecorder(&sr))
+		ctx, span := tp.Tracer("test").Start(context.Background(), "client")
+		ctx = nhtrace.WithClientTrace(ctx, httptrace.NewClientTrace(ctx,
+			httptrace.WithTracerProvider(tp), httptrace.WithHooks(httptrace.HookGetConn)))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+ln.Addr().String(), nil)
+		require.NoError(t, err)
+		res, err := (&http.Client{Transport: &http.Transport{}}).Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		span.End()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.Contains(t, sr, "http.getconn")
+		assert.NotContains(t, sr, "http.receive")
+		var names []string
+		for _, e := range sr["client"][0].Events() {
+			names = append(names, e.Name)
+		}
+		assert.Contains(t, names, "GOT 1xx", "the 1xx response is recorded on the client span")
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		sr := do(t, httptrace.WithPhaseMode(httptrace.PhaseAttributes),
+			httptrace.WithCapturedHeaders("authorization", "x-request-id"))
+		attrs := sr["client"][0].Attributes()
+		assert.Equal(t, kv.StringValue(httptrace.RedactedValue), attrs["http.request.header.authorization"])
+		assert.Equal(t, kv.StringValue("42"), attrs["http.request.header.x_request_id"])
+		assert.NotContains(t, attrs, kv.Key("http.request.header.user_agent"))
+
+		sr = do(t, httptrace.WithPhaseMode(httptrace.PhaseAttributes), httptrace.WithRedactedHeaders())
+		attrs = sr["client"][0].Attributes()
+		assert.Equal(t, kv.StringValue("Bearer secret"), attrs["http.request.header.authorization"])
+		assert.Contains(t, attrs, kv.Key("http.request.header.user_agent"))
+	})
+
+	t.Run("getconn new root", func(t *testing.T) {
+		sr := do(t, httptrace.WithGetConnNewRoot())
+		require.Contains(t, sr, "http.getconn")
+		client, getConn := sr["client"][0], sr["http.getconn"][0]
+		assert.NotEqual(t, client.SpanContext().TraceID, getConn.SpanContext().TraceID)
+		assert.Contains(t, getConn.Links(), client.SpanContext())
+		require.Contains(t, sr, "http.connect")
+		assert.Equal(t, getConn.SpanContext().SpanID, sr["http.connect"][0].ParentSpanID())
+	})
+}
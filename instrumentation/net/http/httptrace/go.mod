@@ -7,6 +7,7 @@ replace go.opentelemetry.io/contrib => ../../../..
 require (
 	github.com/google/go-cmp v0.5.1
 	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/contrib v0.10.0
 	go.opentelemetry.io/otel v0.10.0
 	google.golang.org/grpc v1.31.0
 )
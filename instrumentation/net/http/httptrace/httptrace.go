@@ -17,6 +17,8 @@ package httptrace
 import (
 	"context"
 	"net/http"
+	"net/textproto"
+	"strings"
 
 	"go.opentelemetry.io/otel/api/correlation"
 	"go.opentelemetry.io/otel/api/global"
@@ -27,15 +29,73 @@ import (
 )
 
 // Option is a function that allows configuration of the httptrace Extract()
-// and Inject() functions
+// and Inject() functions and of the client traces returned by
+// NewClientTrace()
 type Option func(*config)
 
+// instrumentationName is the name of the tracer of client traces.
+const instrumentationName = "go.opentelemetry.io/otel/instrumentation/httptrace"
+
+// RedactedValue replaces the value of redacted headers in client traces.
+const RedactedValue = "REDACTED"
+
+// DefaultRedactedHeaders are the headers whose values are redacted by client
+// traces unless WithRedactedHeaders is used.
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
 type config struct {
-	propagators propagation.Propagators
+	propagators    propagation.Propagators
+	tracer         trace.Tracer
+	provider       trace.Provider
+	phaseMode      PhaseMode
+	metrics        *clientMetrics
+	hooks          map[Hook]bool
+	headers        headerFilter
+	getConnNewRoot bool
+}
+
+// headerFilter selects the request headers recorded by a client trace and
+// redacts sensitive ones. A nil allowed set allows every header.
+type headerFilter struct {
+	allowed  map[string]bool
+	redacted map[string]bool
+}
+
+// value returns the attribute value of header k with values v, and false if
+// the header is not recorded.
+func (f headerFilter) value(k string, v []string) (string, bool) {
+	k = textproto.CanonicalMIMEHeaderKey(k)
+	if f.allowed != nil && !f.allowed[k] {
+		return "", false
+	}
+	if f.redacted[k] {
+		return RedactedValue, true
+	}
+	return sliceToString(v), true
+}
+
+// headerKeyPrefix prefixes the attribute keys of recorded headers, so that
+// they do not overwrite attributes such as http.host.
+const headerKeyPrefix = "http.request.header."
+
+// headerKey returns the attribute key of header k.
+func headerKey(k string) kv.Key {
+	return kv.Key(headerKeyPrefix + strings.ReplaceAll(strings.ToLower(k), "-", "_"))
+}
+
+func headerSet(headers []string) map[string]bool {
+	set := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		set[textproto.CanonicalMIMEHeaderKey(h)] = true
+	}
+	return set
 }
 
 func newConfig(opts []Option) *config {
-	c := &config{propagators: global.Propagators()}
+	c := &config{
+		propagators: global.Propagators(),
+		headers:     headerFilter{redacted: headerSet(DefaultRedactedHeaders)},
+	}
 	for _, o := range opts {
 		o(c)
 	}
@@ -49,6 +109,80 @@ func WithPropagators(props propagation.Propagators) Option {
 	}
 }
 
+// WithTracer sets the tracer used by client traces to create phase spans.
+// If this option isn't specified the global tracer is used.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
+// WithTracerProvider sets the provider of the tracer used by client traces
+// to create phase spans. WithTracer takes precedence over it. If neither is
+// specified the global trace provider is used.
+func WithTracerProvider(provider trace.Provider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// WithCapturedHeaders restricts the request headers recorded as attributes by
+// client traces to the provided ones. Headers are recorded under
+// "http.request.header." followed by their lowercased name with dashes
+// replaced by underscores, as in the net/http instrumentation. Every header is recorded if this option
+// isn't specified, and none if it is specified without headers.
+func WithCapturedHeaders(headers ...string) Option {
+	return func(c *config) {
+		if c.headers.allowed == nil {
+			c.headers.allowed = map[string]bool{}
+		}
+		for h := range headerSet(headers) {
+			c.headers.allowed[h] = true
+		}
+	}
+}
+
+// WithRedactedHeaders sets the request headers whose values are replaced by
+// RedactedValue in client traces, replacing DefaultRedactedHeaders.
+func WithRedactedHeaders(headers ...string) Option {
+	return func(c *config) {
+		c.headers.redacted = headerSet(headers)
+	}
+}
+
+// WithHooks restricts the phases recorded on spans by client traces to the
+// provided hooks. Every phase is recorded if this option isn't specified.
+// Metrics are recorded for every phase regardless.
+func WithHooks(hooks ...Hook) Option {
+	return func(c *config) {
+		if c.hooks == nil {
+			c.hooks = map[Hook]bool{}
+		}
+		for _, h := range hooks {
+			c.hooks[h] = true
+		}
+	}
+}
+
+// WithGetConnNewRoot starts the http.getconn span of client traces, which
+// parents the DNS, connect and TLS spans, as the root of a new trace linked
+// to the span of the client trace context, rather than as its child. This
+// keeps connection setup, which may be shared by several requests, out of the
+// request trace.
+func WithGetConnNewRoot() Option {
+	return func(c *config) {
+		c.getConnNewRoot = true
+	}
+}
+
+// WithPhaseMode sets how client traces record the phases of a request. If
+// this option isn't specified each phase is recorded as a span.
+func WithPhaseMode(mode PhaseMode) Option {
+	return func(c *config) {
+		c.phaseMode = mode
+	}
+}
+
 // Returns the Attributes, Context Entries, and SpanContext that were encoded by Inject.
 func Extract(ctx context.Context, req *http.Request, opts ...Option) ([]kv.KeyValue, []kv.KeyValue, trace.SpanContext) {
 	c := newConfig(opts)
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httptrace
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/standard"
+)
+
+// Connection metric label keys
+var (
+	HTTPConnectionReused  = kv.Key("http.connection.reused")
+	HTTPConnectionWasIdle = kv.Key("http.connection.was_idle")
+)
+
+// Client connection metrics
+const (
+	ClientConnections     = "http.client.connections"          // Connections obtained for requests, by reuse
+	ClientIdleTime        = "http.client.connection.idle_time" // Idle time of reused idle connections, microseconds
+	ClientDNSDuration     = "http.client.dns.duration"         // DNS lookup duration, microseconds
+	ClientConnectDuration = "http.client.connect.duration"     // Dial duration, microseconds
+	ClientConnectErrors   = "http.client.connect.errors"       // Failed dials
+	ClientTLSDuration     = "http.client.tls.duration"         // TLS handshake duration, microseconds
+)
+
+// clientMetrics holds the connection instruments shared by client traces.
+// A nil *clientMetrics records nothing.
+type clientMetrics struct {
+	connections     metric.Int64Counter
+	idleTime        metric.Int64ValueRecorder
+	dnsDuration     metric.Int64ValueRecorder
+	connectDuration metric.Int64ValueRecorder
+	connectErrors   metric.Int64Counter
+	tlsDuration     metric.Int64ValueRecorder
+}
+
+func handleErr(err error) {
+	if err != nil {
+		global.Handle(err)
+	}
+}
+
+// WithMeter configures client traces to record connection metrics with the
+// provided meter: connection reuse, idle time of reused connections, DNS,
+// dial and TLS handshake durations and dial errors, labeled by host.
+// Metrics are recorded whatever the phase mode, so that PhaseNone produces
+// metrics without any span data. The instruments are created when the option
+// is created, which should therefore be reused across client traces.
+func WithMeter(meter metric.Meter) Option {
+	m := newClientMetrics(meter)
+	return func(c *config) {
+		c.metrics = m
+	}
+}
+
+func newClientMetrics(meter metric.Meter) *clientMetrics {
+	if meter.MeterImpl() == nil {
+		return nil
+	}
+
+	m := &clientMetrics{}
+	var err error
+
+	m.connections, err = meter.NewInt64Counter(ClientConnections)
+	handleErr(err)
+
+	m.idleTime, err = meter.NewInt64ValueRecorder(ClientIdleTime)
+	handleErr(err)
+
+	m.dnsDuration, err = meter.NewInt64ValueRecorder(ClientDNSDuration)
+	handleErr(err)
+
+	m.connectDuration, err = meter.NewInt64ValueRecorder(ClientConnectDuration)
+	handleErr(err)
+
+	m.connectErrors, err = meter.NewInt64Counter(ClientConnectErrors)
+	handleErr(err)
+
+	m.tlsDuration, err = meter.NewInt64ValueRecorder(ClientTLSDuration)
+	handleErr(err)
+
+	return m
+}
+
+// recordConnection records a connection obtained for a request to host.
+func (m *clientMetrics) recordConnection(ctx context.Context, host string, reused, wasIdle bool, idleTime time.Duration) {
+	if m == nil {
+		return
+	}
+	hostLabel := standard.HTTPHostKey.String(host)
+	m.connections.Add(ctx, 1, hostLabel, HTTPConnectionReused.Bool(reused), HTTPConnectionWasIdle.Bool(wasIdle))
+	if wasIdle {
+		m.idleTime.Record(ctx, idleTime.Microseconds(), hostLabel)
+	}
+}
+
+// recordDuration records the duration of a phase started at start with the
+// recorder selected by phase.
+func (m *clientMetrics) recordDuration(ctx context.Context, phase, host string, start time.Time) {
+	if m == nil || start.IsZero() {
+		return
+	}
+	var r metric.Int64ValueRecorder
+	switch phase {
+	case "http.dns":
+		r = m.dnsDuration
+	case "http.connect":
+		r = m.connectDuration
+	case "http.tls":
+		r = m.tlsDuration
+	default:
+		return
+	}
+	r.Record(ctx, time.Since(start).Microseconds(), standard.HTTPHostKey.String(host))
+}
+
+// recordConnectError records a failed dial for a request to host.
+func (m *clientMetrics) recordConnectError(ctx context.Context, host string) {
+	if m == nil {
+		return
+	}
+	m.connectErrors.Add(ctx, 1, standard.HTTPHostKey.String(host))
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httptrace_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	nhtrace "net/http/httptrace"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace"
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
+)
+
+func TestClientTraceMetrics(t *testing.T) {
+	meterimpl, meter := mockmeter.NewMeter()
+	sr := SpanRecorder{}
+	tr := testtrace.NewProvider(testtrace.WithSpanRecorder(&sr)).Tracer("httptrace/client")
+	opts := []httptrace.Option{
+		httptrace.WithTracer(tr),
+		httptrace.WithMeter(meter),
+		httptrace.WithPhaseMode(httptrace.PhaseNone),
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	client := &http.Client{Transport: &http.Transport{}}
+
+	for i := 0; i < 2; i++ {
+		ctx := context.Background()
+		ctx = nhtrace.WithClientTrace(ctx, httptrace.NewClientTrace(ctx, opts...))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+	}
+
+	ct := httptrace.NewClientTrace(context.Background(), opts...)
+	ct.GetConn("unreachable:80")
+	ct.ConnectStart("tcp", "192.0.2.1:80")
+	ct.ConnectDone("tcp", "192.0.2.1:80", errors.New("connection refused"))
+
+	assert.Empty(t, sr, "no span must be created")
+
+	host := kv.Key("http.host")
+	reused := map[bool]int64{}
+	got := map[string]int64{}
+	for _, batch := range meterimpl.MeasurementBatches {
+		labels := map[kv.Key]kv.Value{}
+		for _, l := range batch.Labels {
+			labels[l.Key] = l.Value
+		}
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			got[name]++
+			switch name {
+			case httptrace.ClientConnections:
+				assert.Equal(t, kv.StringValue(ts.Listener.Addr().String()), labels[host])
+				reused[labels[httptrace.HTTPConnectionReused].AsBool()] += m.Number.AsInt64()
+			case httptrace.ClientConnectErrors:
+				assert.Equal(t, kv.StringValue("unreachable:80"), labels[host])
+			}
+		}
+	}
+
+	assert.Equal(t, map[bool]int64{false: 1, true: 1}, reused)
+	assert.Equal(t, int64(1), got[httptrace.ClientIdleTime])
+	assert.Equal(t, int64(2), got[httptrace.ClientConnectDuration])
+	assert.Equal(t, int64(1), got[httptrace.ClientConnectErrors])
+}
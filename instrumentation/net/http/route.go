@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+)
+
+// RouteResolver resolves the route template matched by a request, such as
+// "/users/{id}", to be used as a low-cardinality span name, http.route
+// attribute and metric label. ResolveRoute returns an empty string if the
+// request does not match any route.
+type RouteResolver interface {
+	ResolveRoute(*http.Request) string
+}
+
+// RouteResolverFunc is an adapter to allow the use of an ordinary function as
+// a RouteResolver.
+type RouteResolverFunc func(*http.Request) string
+
+// ResolveRoute calls f(r).
+func (f RouteResolverFunc) ResolveRoute(r *http.Request) string {
+	return f(r)
+}
+
+// NewServeMuxRouteResolver returns a RouteResolver that resolves the pattern
+// registered in mux that matches a request.
+func NewServeMuxRouteResolver(mux *http.ServeMux) RouteResolver {
+	return RouteResolverFunc(func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return pattern
+	})
+}
+
+type routeKeyType int
+
+const routeKey routeKeyType = 0
+
+// ContextWithRoute returns a copy of parent in which the route template
+// matched by the current request is stored.
+func ContextWithRoute(parent context.Context, route string) context.Context {
+	return context.WithValue(parent, routeKey, route)
+}
+
+// RouteFromContext returns the route template stored in ctx by the Handler
+// when a RouteResolver is configured, or an empty string.
+func RouteFromContext(ctx context.Context) string {
+	route, _ := ctx.Value(routeKey).(string)
+	return route
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
+	mocktrace "go.opentelemetry.io/contrib/internal/trace"
+)
+
+func TestServeMuxRouteResolver(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/", func(http.ResponseWriter, *http.Request) {})
+	rr := NewServeMuxRouteResolver(mux)
+
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/users/42", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/users/", rr.ResolveRoute(r))
+
+	r, err = http.NewRequest(http.MethodGet, "http://localhost/unknown", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "", rr.ResolveRoute(r))
+}
+
+func TestHandlerRouteResolver(t *testing.T) {
+	var span *mocktrace.Span
+	tracer := mocktrace.Tracer{
+		OnSpanStarted: func(s *mocktrace.Span) {
+			span = s
+		},
+	}
+	meterimpl, meter := mockmeter.NewMeter()
+
+	var handlerRoute string
+	h := NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlerRoute = RouteFromContext(r.Context())
+		}), "test_handler",
+		WithTracer(&tracer),
+		WithMeter(meter),
+		WithRouteResolver(RouteResolverFunc(func(*http.Request) string {
+			return "/users/{id}"
+		})),
+	)
+
+	r, err := http.NewRequest(http.MethodGet, "http://localhost/users/42", nil)
+	require.NoError(t, err)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.NotNil(t, span)
+	assert.Equal(t, "/users/{id}", span.Name)
+	assert.Equal(t, kv.StringValue("/users/{id}"), span.Attributes[standard.HTTPRouteKey])
+	assert.Equal(t, "/users/{id}", handlerRoute)
+
+	require.NotEmpty(t, meterimpl.MeasurementBatches)
+	for _, batch := range meterimpl.MeasurementBatches {
+		assert.Contains(t, batch.Labels, standard.HTTPRouteKey.String("/users/{id}"))
+	}
+}
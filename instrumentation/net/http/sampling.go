@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+
+	"go.opentelemetry.io/contrib/internal/sampling"
+)
+
+// SamplingPriorityKey is the span attribute set to 1 on spans forced by
+// SamplingForce. The samplers of the SDK ignore it: wrap the sampler of the
+// provider with the PrioritySampler of the
+// go.opentelemetry.io/contrib/sdk/sampling package to sample forced spans.
+const SamplingPriorityKey = sampling.PriorityKey
+
+// SamplingDecision is the outcome of a SamplingHint for a request.
+type SamplingDecision int
+
+const (
+	// SamplingDefault leaves the decision to the configured sampler.
+	SamplingDefault = SamplingDecision(sampling.Default)
+	// SamplingDrop does not trace the request, as if a Filter rejected it.
+	SamplingDrop = SamplingDecision(sampling.Drop)
+	// SamplingRecord asks for the span to be recorded even if the sampler
+	// does not sample it.
+	SamplingRecord = SamplingDecision(sampling.Record)
+	// SamplingForce records the span and sets SamplingPriorityKey on it
+	// before it starts. The span is only sampled, and thus exported, if the
+	// configured sampler honors SamplingPriorityKey, as PrioritySampler does.
+	SamplingForce = SamplingDecision(sampling.Force)
+)
+
+// SamplingHint is called for every request that is not rejected by a Filter,
+// before its span starts. It returns the sampling decision for the request
+// and attributes that are set on the span at start, and are thus visible to
+// the sampler.
+type SamplingHint func(*http.Request) (SamplingDecision, []kv.KeyValue)
+
+// WithSamplingHint configures h to be called for every request to adjust its
+// sampling.
+func WithSamplingHint(h SamplingHint) Option {
+	return OptionFunc(func(c *Config) {
+		c.SamplingHint = h
+	})
+}
+
+// samplingOptions returns the span start options corresponding to the hint
+// for r, and false if r must not be traced. A nil hint adds no options.
+func samplingOptions(h SamplingHint, r *http.Request) ([]trace.StartOption, bool) {
+	if h == nil {
+		return nil, true
+	}
+	decision, attrs := h(r)
+	return sampling.StartOptions(sampling.Decision(decision), attrs)
+}
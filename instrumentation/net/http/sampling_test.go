@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+
+	mocktrace "go.opentelemetry.io/contrib/internal/trace"
+)
+
+func TestSamplingOptions(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	attr := kv.String("tenant", "acme")
+
+	for _, tc := range []struct {
+		name       string
+		decision   SamplingDecision
+		traced     bool
+		record     bool
+		attributes []kv.KeyValue
+	}{
+		{"default", SamplingDefault, true, false, []kv.KeyValue{attr}},
+		{"drop", SamplingDrop, false, false, nil},
+		{"record", SamplingRecord, true, true, []kv.KeyValue{attr}},
+		{"force", SamplingForce, true, true, []kv.KeyValue{attr, SamplingPriorityKey.Int(1)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hint := func(*http.Request) (SamplingDecision, []kv.KeyValue) {
+				return tc.decision, []kv.KeyValue{attr}
+			}
+			opts, traced := samplingOptions(hint, r)
+			require.Equal(t, tc.traced, traced)
+
+			var cfg trace.StartConfig
+			for _, o := range opts {
+				o(&cfg)
+			}
+			assert.Equal(t, tc.record, cfg.Record)
+			assert.Equal(t, tc.attributes, cfg.Attributes)
+		})
+	}
+
+	opts, traced := samplingOptions(nil, r)
+	assert.True(t, traced)
+	assert.Empty(t, opts)
+}
+
+func TestHandlerSamplingHint(t *testing.T) {
+	tracer := mocktrace.NewTracer("test")
+	var called bool
+	h := NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}), "test_handler",
+		WithTracer(tracer),
+		WithSamplingHint(func(r *http.Request) (SamplingDecision, []kv.KeyValue) {
+			if strings.HasPrefix(r.URL.Path, "/static/") {
+				return SamplingDrop, nil
+			}
+			return SamplingForce, []kv.KeyValue{kv.String("route.class", "api")}
+		}),
+	)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/static/app.js", nil))
+	assert.True(t, called)
+	assert.Empty(t, tracer.EndedSpans())
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users", nil))
+	spans := tracer.EndedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, kv.StringValue("api"), spans[0].Attributes["route.class"])
+	assert.Equal(t, kv.IntValue(1), spans[0].Attributes[SamplingPriorityKey])
+}
+
+func TestTransportSamplingHint(t *testing.T) {
+	tracer := mocktrace.NewTracer("test")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	c := http.Client{Transport: NewTransport(http.DefaultTransport,
+		WithTracer(tracer),
+		WithSamplingHint(func(*http.Request) (SamplingDecision, []kv.KeyValue) {
+			return SamplingDrop, nil
+		}),
+	)}
+	res, err := c.Get(ts.URL)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Empty(t, tracer.EndedSpans())
+}
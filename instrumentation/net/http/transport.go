@@ -16,10 +16,18 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	otelhttptrace "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace"
 
 	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/standard"
 	"go.opentelemetry.io/otel/api/trace"
@@ -33,10 +41,20 @@ type Transport struct {
 	rt http.RoundTripper
 
 	tracer            trace.Tracer
+	meter             metric.Meter
 	propagators       propagation.Propagators
 	spanStartOptions  []trace.StartOption
 	filters           []Filter
+	samplingHint      SamplingHint
 	spanNameFormatter func(string, *http.Request) string
+	requestHeaders    valueCapture
+	responseHeaders   valueCapture
+	queryParameters   valueCapture
+	clientTrace       bool
+	clientTraceOpts   []otelhttptrace.Option
+	counters          map[string]metric.Int64Counter
+	upDownCounters    map[string]metric.Int64UpDownCounter
+	valueRecorders    map[string]metric.Int64ValueRecorder
 }
 
 var _ http.RoundTripper = &Transport{}
@@ -48,25 +66,60 @@ func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
 		rt: base,
 	}
 
+	const domain = "go.opentelemetry.io/contrib/instrumentation/net/http"
+
 	defaultOpts := []Option{
-		WithTracer(global.Tracer("go.opentelemetry.io/contrib/instrumentation/net/http")),
+		WithTracer(global.Tracer(domain)),
+		WithMeter(global.Meter(domain)),
 		WithPropagators(global.Propagators()),
 		WithSpanOptions(trace.WithSpanKind(trace.SpanKindClient)),
 		WithSpanNameFormatter(defaultTransportFormatter),
+		WithRedactedHeaders(DefaultRedactedHeaders...),
 	}
 
 	c := NewConfig(append(defaultOpts, opts...)...)
 	t.configure(c)
+	t.createMeasures()
 
 	return &t
 }
 
 func (t *Transport) configure(c *Config) {
 	t.tracer = c.Tracer
+	t.meter = c.Meter
 	t.propagators = c.Propagators
 	t.spanStartOptions = c.SpanStartOptions
 	t.filters = c.Filters
+	t.samplingHint = c.SamplingHint
 	t.spanNameFormatter = c.SpanNameFormatter
+	t.requestHeaders = newHeaderCapture(RequestHeaderKeyPrefix, c.CapturedRequestHeaders, c.RedactedHeaders)
+	t.responseHeaders = newHeaderCapture(ResponseHeaderKeyPrefix, c.CapturedResponseHeaders, c.RedactedHeaders)
+	t.queryParameters = newQueryCapture(c.CapturedQueryParameters)
+	t.clientTrace = c.ClientTrace
+	t.clientTraceOpts = append([]otelhttptrace.Option{otelhttptrace.WithTracer(c.Tracer)}, c.ClientTraceOptions...)
+}
+
+func (t *Transport) createMeasures() {
+	t.counters = make(map[string]metric.Int64Counter)
+	t.upDownCounters = make(map[string]metric.Int64UpDownCounter)
+	t.valueRecorders = make(map[string]metric.Int64ValueRecorder)
+
+	requestBytesCounter, err := t.meter.NewInt64Counter(ClientRequestContentLength)
+	handleErr(err)
+
+	responseBytesCounter, err := t.meter.NewInt64Counter(ClientResponseContentLength)
+	handleErr(err)
+
+	activeRequestsCounter, err := t.meter.NewInt64UpDownCounter(ClientActiveRequests)
+	handleErr(err)
+
+	clientLatencyMeasure, err := t.meter.NewInt64ValueRecorder(ClientLatency)
+	handleErr(err)
+
+	t.counters[ClientRequestContentLength] = requestBytesCounter
+	t.counters[ClientResponseContentLength] = responseBytesCounter
+	t.upDownCounters[ClientActiveRequests] = activeRequestsCounter
+	t.valueRecorders[ClientLatency] = clientLatencyMeasure
 }
 
 func defaultTransportFormatter(_ string, r *http.Request) string {
@@ -76,7 +129,10 @@ func defaultTransportFormatter(_ string, r *http.Request) string {
 // RoundTrip creates a Span and propagates its context via the provided request's headers
 // before handing the request to the configured base RoundTripper. The created span will
 // end when the response body is closed or when a read from the body returns io.EOF.
+// Client metrics are recorded at the same point, so the reported duration includes
+// the time taken to consume the response body.
 func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	requestStartTime := time.Now()
 	for _, f := range t.filters {
 		if !f(r) {
 			// Simply pass through to the base RoundTripper if a filter rejects the request
@@ -84,44 +140,111 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 		}
 	}
 
+	samplingOpts, ok := samplingOptions(t.samplingHint, r)
+	if !ok {
+		return t.rt.RoundTrip(r)
+	}
+
 	opts := append([]trace.StartOption{}, t.spanStartOptions...) // start with the configured options
+	opts = append(opts, samplingOpts...)
 
 	ctx, span := t.tracer.Start(r.Context(), t.spanNameFormatter("", r), opts...)
+	if t.clientTrace {
+		ctx = httptrace.WithClientTrace(ctx, otelhttptrace.NewClientTrace(ctx, t.clientTraceOpts...))
+	}
 
 	r = r.WithContext(ctx)
 	span.SetAttributes(standard.HTTPClientAttributesFromHTTPRequest(r)...)
+	span.SetAttributes(t.requestHeaders.headerAttributes(r.Header)...)
+	span.SetAttributes(t.queryParameters.queryAttributes(r.URL)...)
 	propagation.InjectHTTP(ctx, t.propagators, r.Header)
 
+	labels := clientMetricLabels(r)
+	t.upDownCounters[ClientActiveRequests].Add(ctx, 1, labels...)
+
 	res, err := t.rt.RoundTrip(r)
 	if err != nil {
 		span.RecordError(ctx, err, trace.WithErrorStatus(codes.Internal))
 		span.End()
+		t.recordMetrics(ctx, r, 0, requestStartTime, labels)
 		return res, err
 	}
 
 	span.SetAttributes(standard.HTTPAttributesFromHTTPStatusCode(res.StatusCode)...)
+	span.SetAttributes(t.responseHeaders.headerAttributes(res.Header)...)
 	span.SetStatus(standard.SpanStatusFromHTTPStatusCode(res.StatusCode))
-	res.Body = &wrappedBody{ctx: ctx, span: span, body: res.Body}
+	res.Body = &wrappedBody{ctx: ctx, span: span, body: res.Body, record: func(read int64) {
+		t.recordMetrics(ctx, r, read, requestStartTime, labels)
+	}}
 
 	return res, err
 }
 
+// clientMetricLabels returns the low-cardinality labels of the client
+// metrics of r. The scheme is taken from the request URL, since the TLS
+// connection state of outgoing requests is never set.
+func clientMetricLabels(r *http.Request) []kv.KeyValue {
+	labels := []kv.KeyValue{standard.HTTPMethodKey.String(r.Method)}
+	if r.URL.Scheme != "" {
+		labels = append(labels, standard.HTTPSchemeKey.String(r.URL.Scheme))
+	}
+	host := r.Host
+	if host == "" {
+		host = r.URL.Host
+	}
+	if host != "" {
+		labels = append(labels, standard.HTTPHostKey.String(host))
+	}
+	switch r.ProtoMajor {
+	case 1:
+		labels = append(labels, standard.HTTPFlavorKey.String(fmt.Sprintf("1.%d", r.ProtoMinor)))
+	case 2:
+		labels = append(labels, standard.HTTPFlavorKey.String("2"))
+	}
+	return labels
+}
+
+// recordMetrics records the client metrics of a finished request. Only the
+// declared content length of the request is recorded, since the base
+// RoundTripper may still be writing the body after RoundTrip returns.
+func (t *Transport) recordMetrics(ctx context.Context, r *http.Request, read int64, start time.Time, labels []kv.KeyValue) {
+	var written int64
+	if r.ContentLength > 0 {
+		written = r.ContentLength
+	}
+
+	t.upDownCounters[ClientActiveRequests].Add(ctx, -1, labels...)
+	t.counters[ClientRequestContentLength].Add(ctx, written, labels...)
+	t.counters[ClientResponseContentLength].Add(ctx, read, labels...)
+
+	elapsedTime := time.Since(start).Microseconds()
+
+	t.valueRecorders[ClientLatency].Record(ctx, elapsedTime, labels...)
+}
+
+// wrappedBody wraps a http.Response.Body to end the span and record the
+// client metrics once the body has been consumed or closed.
 type wrappedBody struct {
-	ctx  context.Context
-	span trace.Span
-	body io.ReadCloser
+	ctx    context.Context
+	span   trace.Span
+	body   io.ReadCloser
+	record func(read int64) // must not be nil
+
+	read int64
+	once sync.Once
 }
 
 var _ io.ReadCloser = &wrappedBody{}
 
 func (wb *wrappedBody) Read(b []byte) (int, error) {
 	n, err := wb.body.Read(b)
+	wb.read += int64(n)
 
 	switch err {
 	case nil:
 		// nothing to do here but fall through to the return
 	case io.EOF:
-		wb.span.End()
+		wb.end()
 	default:
 		wb.span.RecordError(wb.ctx, err, trace.WithErrorStatus(codes.Internal))
 	}
@@ -129,6 +252,13 @@ func (wb *wrappedBody) Read(b []byte) (int, error) {
 }
 
 func (wb *wrappedBody) Close() error {
-	wb.span.End()
+	wb.end()
 	return wb.body.Close()
 }
+
+func (wb *wrappedBody) end() {
+	wb.once.Do(func() {
+		wb.span.End()
+		wb.record(wb.read)
+	})
+}
@@ -20,12 +20,21 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	otelhttptrace "go.opentelemetry.io/contrib/instrumentation/net/http/httptrace"
+
 	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
 	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/standard"
 	"go.opentelemetry.io/otel/api/trace"
 
+	mockmeter "go.opentelemetry.io/contrib/internal/metric"
 	mocktrace "go.opentelemetry.io/contrib/internal/trace"
 )
 
@@ -74,3 +83,120 @@ func TestTransportBasics(t *testing.T) {
 		t.Fatalf("unexpected content: got %s, expected %s", body, content)
 	}
 }
+
+func TestTransportMetrics(t *testing.T) {
+	tracer := mocktrace.Tracer{}
+	meterimpl, meter := mockmeter.NewMeter()
+	content := []byte("Hello, world!")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	r, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	tr := NewTransport(
+		http.DefaultTransport,
+		WithTracer(&tracer),
+		WithMeter(meter),
+	)
+
+	c := http.Client{Transport: tr}
+	res, err := c.Do(r)
+	require.NoError(t, err)
+
+	_, err = ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	got := map[string]int64{}
+	for _, batch := range meterimpl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			got[m.Instrument.Descriptor().Name()] += m.Number.AsInt64()
+		}
+	}
+
+	for _, batch := range meterimpl.MeasurementBatches {
+		assert.Equal(t, []kv.KeyValue{
+			standard.HTTPMethodKey.String(http.MethodPost),
+			standard.HTTPSchemeHTTP,
+			standard.HTTPHostKey.String(r.Host),
+			standard.HTTPFlavorKey.String("1.1"),
+		}, batch.Labels, "labels have no request content length")
+	}
+
+	assert.Equal(t, int64(0), got[ClientActiveRequests])
+	assert.Equal(t, int64(3), got[ClientRequestContentLength])
+	assert.Equal(t, int64(len(content)), got[ClientResponseContentLength])
+	assert.Contains(t, got, ClientLatency)
+}
+
+func TestTransportMetricsHTTPS(t *testing.T) {
+	meterimpl, meter := mockmeter.NewMeter()
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	tr := NewTransport(
+		ts.Client().Transport,
+		WithTracer(&mocktrace.Tracer{}),
+		WithMeter(meter),
+	)
+
+	c := http.Client{Transport: tr}
+	res, err := c.Get(ts.URL)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	require.NotEmpty(t, meterimpl.MeasurementBatches)
+	for _, batch := range meterimpl.MeasurementBatches {
+		assert.Contains(t, batch.Labels, standard.HTTPSchemeHTTPS)
+	}
+}
+
+func TestTransportClientTrace(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	get := func(t *testing.T, tr *Transport) {
+		r, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		res, err := (&http.Client{Transport: tr}).Do(r)
+		require.NoError(t, err)
+		_, err = ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+	}
+
+	t.Run("spans", func(t *testing.T) {
+		tracer := mocktrace.NewTracer("test")
+		get(t, NewTransport(&http.Transport{}, WithTracer(tracer), WithClientTrace()))
+
+		names := map[string]bool{}
+		for _, span := range tracer.EndedSpans() {
+			names[span.Name] = true
+		}
+		assert.True(t, names["http.getconn"], "phase spans must use the transport tracer")
+		assert.True(t, names["http.connect"])
+		assert.True(t, names[http.MethodGet])
+	})
+
+	t.Run("attributes", func(t *testing.T) {
+		tracer := mocktrace.NewTracer("test")
+		get(t, NewTransport(&http.Transport{},
+			WithTracer(tracer),
+			WithClientTrace(otelhttptrace.WithPhaseMode(otelhttptrace.PhaseAttributes)),
+		))
+
+		spans := tracer.EndedSpans()
+		require.Len(t, spans, 1)
+		assert.Contains(t, spans[0].Attributes, kv.Key("http.getconn.duration"))
+		assert.Contains(t, spans[0].Attributes, kv.Key("http.connect.duration"))
+	})
+}
@@ -25,10 +25,11 @@ import (
 var _ io.ReadCloser = &bodyWrapper{}
 
 // bodyWrapper wraps a http.Request.Body (an io.ReadCloser) to track the number
-// of bytes read and the last error
+// of bytes read and the last error, and optionally capture the first bytes read
 type bodyWrapper struct {
 	io.ReadCloser
-	record func(n int64) // must not be nil
+	record  func(n int64) // must not be nil
+	capture *bodyBuffer
 
 	read int64
 	err  error
@@ -40,6 +41,7 @@ func (w *bodyWrapper) Read(b []byte) (int, error) {
 	w.read += n1
 	w.err = err
 	w.record(n1)
+	w.capture.write(b[:n])
 	return n, err
 }
 
@@ -50,13 +52,15 @@ func (w *bodyWrapper) Close() error {
 var _ http.ResponseWriter = &respWriterWrapper{}
 
 // respWriterWrapper wraps a http.ResponseWriter in order to track the number of
-// bytes written, the last error, and to catch the returned statusCode
+// bytes written, the last error, and to catch the returned statusCode and
+// optionally the first bytes written
 // TODO: The wrapped http.ResponseWriter doesn't implement any of the optional
 // types (http.Hijacker, http.Pusher, http.CloseNotifier, http.Flusher, etc)
 // that may be useful when using it in real life situations.
 type respWriterWrapper struct {
 	http.ResponseWriter
-	record func(n int64) // must not be nil
+	record  func(n int64) // must not be nil
+	capture *bodyBuffer
 
 	// used to inject the header
 	ctx context.Context
@@ -80,6 +84,7 @@ func (w *respWriterWrapper) Write(p []byte) (int, error) {
 	n, err := w.ResponseWriter.Write(p)
 	n1 := int64(n)
 	w.record(n1)
+	w.capture.write(p[:n])
 	w.written += n1
 	w.err = err
 	return n, err
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package panics records panics recovered by the HTTP server
+// instrumentation.
+package panics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// Attribute keys of the exception events recording panics.
+const (
+	ExceptionTypeKey       = kv.Key("exception.type")       // the Go type of the panic value
+	ExceptionMessageKey    = kv.Key("exception.message")    // the panic value, formatted with fmt.Sprint
+	ExceptionStacktraceKey = kv.Key("exception.stacktrace") // the stack trace of the panicking goroutine
+)
+
+// Record records the recovered panic value p, and stack, the stack trace of
+// the panicking goroutine, as an exception event on span and sets an
+// Internal error status.
+func Record(ctx context.Context, span trace.Span, p interface{}, stack []byte) {
+	msg := fmt.Sprint(p)
+	span.AddEvent(ctx, "exception",
+		ExceptionTypeKey.String(fmt.Sprintf("%T", p)),
+		ExceptionMessageKey.String(msg),
+		ExceptionStacktraceKey.String(string(stack)),
+	)
+	span.SetStatus(codes.Internal, msg)
+}
+
+// RecordHTTP records p and stack like Record, and sets the status code
+// attributes of a 500 Internal Server Error response on span.
+func RecordHTTP(ctx context.Context, span trace.Span, p interface{}, stack []byte) {
+	Record(ctx, span, p, stack)
+	span.SetAttributes(standard.HTTPAttributesFromHTTPStatusCode(http.StatusInternalServerError)...)
+}
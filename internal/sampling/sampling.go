@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sampling implements the sampling hints of the HTTP and gRPC
+// instrumentation.
+package sampling
+
+import (
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// PriorityKey is the attribute set on spans whose sampling is forced.
+const PriorityKey = kv.Key("sampling.priority")
+
+// Decision is the outcome of a sampling hint.
+type Decision int
+
+const (
+	// Default leaves the decision to the sampler.
+	Default Decision = iota
+	// Drop does not start the span.
+	Drop
+	// Record starts the span with trace.WithRecord.
+	Record
+	// Force starts the span with trace.WithRecord and PriorityKey set to 1.
+	Force
+)
+
+// StartOptions returns the span start options for d and the attributes
+// attrs returned by the hint, and false if the span must not be started.
+func StartOptions(d Decision, attrs []kv.KeyValue) ([]trace.StartOption, bool) {
+	var opts []trace.StartOption
+	switch d {
+	case Drop:
+		return nil, false
+	case Record:
+		opts = append(opts, trace.WithRecord())
+	case Force:
+		opts = append(opts, trace.WithRecord())
+		attrs = append(attrs, PriorityKey.Int(1))
+	}
+	if len(attrs) > 0 {
+		opts = append(opts, trace.WithAttributes(attrs...))
+	}
+	return opts, true
+}
@@ -36,6 +36,13 @@ type Span struct {
 	StatusMessage string
 	ParentSpanID  oteltrace.SpanID
 	Links         map[oteltrace.SpanContext][]otelkv.KeyValue
+	Events        []Event
+}
+
+// Event is an event added to a mock span.
+type Event struct {
+	Name       string
+	Attributes map[otelkv.Key]otelkv.Value
 }
 
 var _ oteltrace.Span = (*Span)(nil)
@@ -95,10 +102,20 @@ func (ms *Span) Tracer() oteltrace.Tracer {
 	return ms.tracer
 }
 
-// AddEvent does nothing.
+// AddEvent adds an event to the Events member.
 func (ms *Span) AddEvent(ctx context.Context, name string, attrs ...otelkv.KeyValue) {
+	ms.AddEventWithTimestamp(ctx, time.Now(), name, attrs...)
 }
 
-// AddEvent does nothing.
+// AddEventWithTimestamp adds an event to the Events member, ignoring the
+// timestamp.
 func (ms *Span) AddEventWithTimestamp(ctx context.Context, timestamp time.Time, name string, attrs ...otelkv.KeyValue) {
+	event := Event{
+		Name:       name,
+		Attributes: make(map[otelkv.Key]otelkv.Value, len(attrs)),
+	}
+	for _, kv := range attrs {
+		event.Attributes[kv.Key] = kv.Value
+	}
+	ms.Events = append(ms.Events, event)
 }
@@ -0,0 +1,12 @@
+module go.opentelemetry.io/contrib/sdk/sampling
+
+go 1.14
+
+require (
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/contrib v0.10.0
+	go.opentelemetry.io/otel v0.10.0
+	go.opentelemetry.io/otel/sdk v0.10.0
+)
+
+replace go.opentelemetry.io/contrib => ../../
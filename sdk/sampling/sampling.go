@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sampling provides SDK samplers for the sampling hints of the
+// instrumentation.
+package sampling
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/api/kv"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"go.opentelemetry.io/contrib/internal/sampling"
+)
+
+// PriorityKey is the attribute set to 1 on the spans forced by the
+// SamplingForce decision of the net/http and gRPC instrumentation.
+const PriorityKey = sampling.PriorityKey
+
+// PrioritySampler returns a sampler that samples the spans started with
+// PriorityKey above zero, and delegates the decision for the other spans to
+// fallback.
+func PrioritySampler(fallback sdktrace.Sampler) sdktrace.Sampler {
+	return prioritySampler{fallback: fallback}
+}
+
+type prioritySampler struct {
+	fallback sdktrace.Sampler
+}
+
+func (ps prioritySampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, attr := range p.Attributes {
+		if attr.Key == PriorityKey && positive(attr.Value) {
+			return sdktrace.SamplingResult{Decision: sdktrace.RecordAndSampled}
+		}
+	}
+	return ps.fallback.ShouldSample(p)
+}
+
+func (ps prioritySampler) Description() string {
+	return fmt.Sprintf("PrioritySampler{%s}", ps.fallback.Description())
+}
+
+// positive reports whether v is an integer above zero.
+func positive(v kv.Value) bool {
+	switch v.Type() {
+	case kv.INT32:
+		return v.AsInt32() > 0
+	case kv.INT64:
+		return v.AsInt64() > 0
+	case kv.UINT32:
+		return v.AsUint32() > 0
+	case kv.UINT64:
+		return v.AsUint64() > 0
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestPrioritySampler(t *testing.T) {
+	sampler := PrioritySampler(sdktrace.NeverSample())
+	assert.Equal(t, "PrioritySampler{AlwaysOffSampler}", sampler.Description())
+
+	for _, tc := range []struct {
+		name    string
+		attrs   []kv.KeyValue
+		sampled bool
+	}{
+		{"no priority", []kv.KeyValue{kv.String("tenant", "acme")}, false},
+		{"forced", []kv.KeyValue{kv.String("tenant", "acme"), PriorityKey.Int(1)}, true},
+		{"forced int64", []kv.KeyValue{PriorityKey.Int64(2)}, true},
+		{"forced uint32", []kv.KeyValue{PriorityKey.Uint32(1)}, true},
+		{"zero priority", []kv.KeyValue{PriorityKey.Int(0)}, false},
+		{"negative priority", []kv.KeyValue{PriorityKey.Int(-1)}, false},
+		{"string priority", []kv.KeyValue{PriorityKey.String("1")}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tp, err := sdktrace.NewProvider(sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sampler}))
+			require.NoError(t, err)
+			_, span := tp.Tracer("test").Start(context.Background(), "span", trace.WithAttributes(tc.attrs...))
+			defer span.End()
+			assert.Equal(t, tc.sampled, span.SpanContext().IsSampled())
+		})
+	}
+}
+
+func TestPrioritySamplerFallback(t *testing.T) {
+	sampler := PrioritySampler(sdktrace.AlwaysSample())
+	res := sampler.ShouldSample(sdktrace.SamplingParameters{Name: "span"})
+	assert.Equal(t, sdktrace.RecordAndSampled, res.Decision)
+}